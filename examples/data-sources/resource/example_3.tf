@@ -0,0 +1,16 @@
+data "kubernetes_resource" "example" {
+  api_version = "v1"
+  kind        = "Secret"
+
+  metadata {
+    name      = "example-tls"
+    namespace = "default"
+  }
+
+  wait {
+    fields = {
+      "data[\"tls.crt\"]" = "*"
+    }
+    timeout = "5m"
+  }
+}
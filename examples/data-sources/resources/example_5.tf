@@ -0,0 +1,16 @@
+data "kubernetes_resources" "example" {
+  api_version    = "v1"
+  kind           = "Pod"
+  all_namespaces = true
+  label_selector = "app.kubernetes.io/part-of=example"
+  page_size      = 100
+  limit          = 500
+}
+
+output "pods" {
+  value = [for pod in data.kubernetes_resources.example.objects : "${pod.metadata.namespace}/${pod.metadata.name}"]
+}
+
+output "truncated" {
+  value = data.kubernetes_resources.example.remaining_item_count != null
+}
@@ -0,0 +1,14 @@
+data "kubernetes_resources" "example" {
+  api_version    = "v1"
+  kind           = "Pod"
+  all_namespaces = true
+  fields = [
+    "metadata.name",
+    "metadata.namespace",
+    "status.phase",
+  ]
+}
+
+output "phases" {
+  value = { for pod in data.kubernetes_resources.example.objects : "${pod.metadata.namespace}/${pod.metadata.name}" => pod.status.phase }
+}
@@ -0,0 +1,11 @@
+data "kubernetes_resources" "example" {
+  api_version    = "v1"
+  kind           = "Secret"
+  namespace      = "kube-system"
+  metadata_only  = true
+  label_selector = "app.kubernetes.io/managed-by=example"
+}
+
+output "secrets" {
+  value = [for secret in data.kubernetes_resources.example.objects : secret.metadata.name]
+}
@@ -0,0 +1,17 @@
+provider "kubernetes" {
+  default_labels = {
+    "team"        = "platform"
+    "cost-center" = "cc-1234"
+    "managed-by"  = "terraform"
+  }
+}
+
+resource "kubernetes_namespace_v1" "example" {
+  metadata {
+    name = "example"
+    labels = {
+      # takes precedence over the default value of the provider
+      "team" = "data"
+    }
+  }
+}
@@ -0,0 +1,10 @@
+provider "kubernetes" {
+  config_path = "~/.kube/config"
+
+  # apply the resources as the tenant's deployer service account,
+  # so that its RBAC permissions are enforced
+  impersonate {
+    user   = "system:serviceaccount:tenant-a:deployer"
+    groups = ["system:serviceaccounts", "system:serviceaccounts:tenant-a", "system:authenticated"]
+  }
+}
@@ -0,0 +1,36 @@
+provider "kubernetes" {
+  config_path     = "~/.kube/config"
+  applyset_parent = "default/platform"
+}
+
+resource "kubernetes_manifests" "monitoring" {
+  content = file("${path.module}/monitoring.yaml")
+}
+
+resource "kubernetes_manifest" "settings" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = "settings"
+      namespace = "default"
+    }
+    data = {
+      level = "info"
+    }
+  }
+}
+
+resource "kubernetes_applyset" "platform" {
+  name      = "platform"
+  namespace = "default"
+
+  members = concat(kubernetes_manifests.monitoring.objects, [
+    {
+      api_version = "v1"
+      kind        = "ConfigMap"
+      name        = kubernetes_manifest.settings.object.metadata.name
+      namespace   = "default"
+    },
+  ])
+}
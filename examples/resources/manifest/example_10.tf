@@ -0,0 +1,14 @@
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    // ...
+  }
+
+  delete_options {
+    propagation_policy   = "Foreground"
+    grace_period_seconds = 30
+  }
+
+  timeouts {
+    delete = "15m"
+  }
+}
@@ -0,0 +1,9 @@
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    // ...
+  }
+
+  wait {
+    expression = "has(object.status.readyReplicas) && object.status.readyReplicas == object.spec.replicas"
+  }
+}
@@ -0,0 +1,18 @@
+resource "kubernetes_manifest" "configmap" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = "sample"
+      namespace = "default"
+    }
+    data = {
+      foo = "bar"
+    }
+  }
+
+  field_manager {
+    # take over the fields set with `kubectl apply` before the ConfigMap was imported
+    migrate_from = ["kubectl-client-side-apply", "kubectl-edit"]
+  }
+}
@@ -0,0 +1,36 @@
+resource "kubernetes_manifest" "deployment" {
+  manifest = {
+    apiVersion = "apps/v1"
+    kind       = "Deployment"
+    metadata = {
+      name      = "web"
+      namespace = "default"
+    }
+    spec = {
+      # scaled by a HorizontalPodAutoscaler once created
+      replicas = 2
+      selector = {
+        matchLabels = {
+          app = "web"
+        }
+      }
+      template = {
+        metadata = {
+          labels = {
+            app = "web"
+          }
+        }
+        spec = {
+          containers = [
+            {
+              name  = "web"
+              image = "nginx:1.27"
+            },
+          ]
+        }
+      }
+    }
+  }
+
+  owned_fields_only = true
+}
@@ -0,0 +1,27 @@
+resource "kubernetes_manifest" "service" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "Service"
+    metadata = {
+      name      = "web"
+      namespace = "default"
+    }
+    spec = {
+      selector = {
+        app = "web"
+      }
+      ports = [
+        {
+          port = 80
+        },
+      ]
+    }
+  }
+
+  plan_with_dry_run = true
+}
+
+# known when planning, rather than after apply
+output "service_target_port" {
+  value = kubernetes_manifest.service.object.spec.ports[0].targetPort
+}
@@ -0,0 +1,15 @@
+resource "kubernetes_manifest" "service" {
+  manifest_yaml = <<-EOT
+    apiVersion: v1
+    kind: Service
+    metadata:
+      name: example
+      namespace: default
+    spec:
+      selector:
+        app: example
+      ports:
+        - port: 80
+          targetPort: 8080
+  EOT
+}
@@ -0,0 +1,15 @@
+resource "kubernetes_manifest" "scale" {
+  subresource = "scale"
+
+  manifest = {
+    apiVersion = "apps/v1"
+    kind       = "Deployment"
+    metadata = {
+      name      = "example"
+      namespace = "default"
+    }
+    spec = {
+      replicas = 3
+    }
+  }
+}
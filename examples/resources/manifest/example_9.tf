@@ -0,0 +1,22 @@
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    // ...
+  }
+
+  wait {
+    condition {
+      type   = "Complete"
+      status = "True"
+    }
+
+    error_on {
+      condition {
+        type   = "Failed"
+        status = "True"
+      }
+      fields = {
+        "status.failed" = "^[1-9]"
+      }
+    }
+  }
+}
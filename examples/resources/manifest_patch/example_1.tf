@@ -0,0 +1,22 @@
+resource "kubernetes_manifest_patch" "webhook_ca_bundle" {
+  manifest = {
+    apiVersion = "admissionregistration.k8s.io/v1"
+    kind       = "ValidatingWebhookConfiguration"
+    metadata = {
+      name = "example-webhook"
+    }
+    webhooks = [
+      {
+        name = "validate.example.com"
+        clientConfig = {
+          caBundle = base64encode(tls_self_signed_cert.ca.cert_pem)
+        }
+      }
+    ]
+  }
+
+  field_manager {
+    name            = "terraform-webhook-ca"
+    force_conflicts = true
+  }
+}
@@ -0,0 +1,26 @@
+resource "kubernetes_manifest_patch" "image" {
+  manifest = {
+    apiVersion = "apps/v1"
+    kind       = "Deployment"
+    metadata = {
+      name      = "example"
+      namespace = "default"
+    }
+    spec = {
+      template = {
+        spec = {
+          containers = [
+            {
+              name  = "app"
+              image = "example/app:1.2.3"
+            }
+          ]
+        }
+      }
+    }
+  }
+
+  field_manager {
+    force_conflicts = true
+  }
+}
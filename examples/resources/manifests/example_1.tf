@@ -0,0 +1,3 @@
+resource "kubernetes_manifests" "cert-manager" {
+  content = file("${path.module}/cert-manager.yaml")
+}
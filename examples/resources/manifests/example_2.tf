@@ -0,0 +1,12 @@
+resource "kubernetes_manifests" "monitoring" {
+  content = join("\n---\n", [
+    for f in fileset("${path.module}/monitoring", "*.yaml") : templatefile("${path.module}/monitoring/${f}", {
+      namespace = "monitoring"
+    })
+  ])
+
+  field_manager {
+    name            = "monitoring-stack"
+    force_conflicts = true
+  }
+}
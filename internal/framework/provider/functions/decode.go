@@ -5,6 +5,7 @@ package functions
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"regexp"
@@ -53,6 +54,32 @@ func decode(ctx context.Context, manifest string) (v types.Tuple, diags diag.Dia
 	return types.TupleValue(dtypes, dvalues)
 }
 
+// DecodeDocuments splits a multi-document YAML stream and decodes each Kubernetes manifest in it,
+// in the order they appear. Empty documents are skipped. Numbers are decoded as json.Number
+// so that they retain their exact representation when the manifests are encoded again.
+func DecodeDocuments(manifest string) ([]map[string]any, error) {
+	docs := documentSeparator.Split(manifest, -1)
+	objs := make([]map[string]any, 0, len(docs))
+	for i, d := range docs {
+		var data map[string]any
+		err := yaml.Unmarshal([]byte(d), &data, func(dec *json.Decoder) *json.Decoder {
+			dec.UseNumber()
+			return dec
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML document #%d: %s", i+1, err)
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if err := validateKubernetesManifest(data); err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes manifest in document #%d: %s", i+1, err)
+		}
+		objs = append(objs, data)
+	}
+	return objs, nil
+}
+
 func decodeMapping(ctx context.Context, m map[string]any) (attr.Value, diag.Diagnostics) {
 	vm := make(map[string]attr.Value, len(m))
 	tm := make(map[string]attr.Type, len(m))
@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDecodeDocuments(t *testing.T) {
+	data, err := os.ReadFile("testdata/decode_multi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs, err := DecodeDocuments(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"DaemonSet", "ConfigMap", "DaemonSet"}
+	if len(objs) != len(expected) {
+		t.Fatalf("expected %d documents, got %d", len(expected), len(objs))
+	}
+	for i, k := range expected {
+		if objs[i]["kind"] != k {
+			t.Errorf("document #%d: expected kind %q, got %q", i, k, objs[i]["kind"])
+		}
+	}
+
+	for _, f := range []string{"testdata/decode_manifest_invalid.yaml", "testdata/decode_manifest_invalid_syntax.yaml"} {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := DecodeDocuments(string(data)); err == nil {
+			t.Errorf("expected error decoding %s", f)
+		}
+	}
+}
@@ -51,6 +51,7 @@ type KubernetesProviderModel struct {
 
 	ConfigPaths []types.String `tfsdk:"config_paths"`
 	ConfigPath  types.String   `tfsdk:"config_path"`
+	ConfigRaw   types.String   `tfsdk:"config_raw"`
 
 	ConfigContext         types.String `tfsdk:"config_context"`
 	ConfigContextAuthInfo types.String `tfsdk:"config_context_auth_info"`
@@ -63,6 +64,18 @@ type KubernetesProviderModel struct {
 	IgnoreAnnotations types.List `tfsdk:"ignore_annotations"`
 	IgnoreLabels      types.List `tfsdk:"ignore_labels"`
 
+	DefaultAnnotations types.Map `tfsdk:"default_annotations"`
+	DefaultLabels      types.Map `tfsdk:"default_labels"`
+
+	OfflineSchemaVersion types.String `tfsdk:"offline_schema_version"`
+	OpenAPICacheDir      types.String `tfsdk:"openapi_cache_dir"`
+	ApplySetParent       types.String `tfsdk:"applyset_parent"`
+
+	QPS            types.Float64 `tfsdk:"qps"`
+	Burst          types.Int64   `tfsdk:"burst"`
+	RequestTimeout types.String  `tfsdk:"request_timeout"`
+	MaxRetries     types.Int64   `tfsdk:"max_retries"`
+
 	Exec []struct {
 		APIVersion types.String            `tfsdk:"api_version"`
 		Command    types.String            `tfsdk:"command"`
@@ -70,6 +83,16 @@ type KubernetesProviderModel struct {
 		Args       []types.String          `tfsdk:"args"`
 	} `tfsdk:"exec"`
 
+	Impersonate []struct {
+		User   types.String   `tfsdk:"user"`
+		UID    types.String   `tfsdk:"uid"`
+		Groups []types.String `tfsdk:"groups"`
+		Extra  []struct {
+			Key    types.String   `tfsdk:"key"`
+			Values []types.String `tfsdk:"values"`
+		} `tfsdk:"extra"`
+	} `tfsdk:"impersonate"`
+
 	Experiments []struct {
 		ManifestResource types.Bool `tfsdk:"manifest_resource"`
 	} `tfsdk:"experiments"`
@@ -124,6 +147,11 @@ func (p *KubernetesProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Description: "Path to the kube config file. Can be set with KUBE_CONFIG_PATH.",
 				Optional:    true,
 			},
+			"config_raw": schema.StringAttribute{
+				Description: "Content of a kube config file, as a string. Conflicts with `config_path` and `config_paths`, and the context to use is selected with the `config_context*` attributes. Can be sourced from `KUBE_CONFIG_RAW`, which takes precedence over the kube config paths.",
+				Optional:    true,
+				Sensitive:   true,
+			},
 			"config_context": schema.StringAttribute{
 				Description: "",
 				Optional:    true,
@@ -154,6 +182,44 @@ func (p *KubernetesProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Description: "List of Kubernetes metadata labels to ignore across all resources handled by this provider for situations where external systems are managing certain resource labels. Each item is a regular expression.",
 				Optional:    true,
 			},
+			"default_annotations": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Map of annotations added to the metadata of every object managed by this provider. Annotations set on a resource take precedence over the ones set here.",
+				Optional:    true,
+			},
+			"default_labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Map of labels added to the metadata of every object managed by this provider. Labels set on a resource take precedence over the ones set here.",
+				Optional:    true,
+			},
+			"offline_schema_version": schema.StringAttribute{
+				Description: "Kubernetes minor version (e.g. `1.33`) of the OpenAPI schema bundled with the provider. When set, `kubernetes_manifest` resources of built-in kinds are planned against the bundled schema instead of contacting the API server. Can be sourced from `KUBE_OFFLINE_SCHEMA_VERSION`.",
+				Optional:    true,
+			},
+			"openapi_cache_dir": schema.StringAttribute{
+				Description: "Path to a directory where OpenAPI documents retrieved from the API server are cached across provider runs. Cached documents are keyed by the API server address and version, and revalidated with the API server before use. Can be sourced from `KUBE_OPENAPI_CACHE_DIR`.",
+				Optional:    true,
+			},
+			"applyset_parent": schema.StringAttribute{
+				Description: "Reference to the Secret, in `<namespace>/<name>` format, that is the parent of the ApplySet that objects created by this provider are labelled as members of. See the `kubernetes_applyset` resource. Can be sourced from `KUBE_APPLYSET_PARENT`.",
+				Optional:    true,
+			},
+			"qps": schema.Float64Attribute{
+				Description: "Maximum number of requests per second to the Kubernetes API server, once the `burst` allowance is used up. Defaults to 5. A negative value disables client-side rate limiting. Can be sourced from `KUBE_QPS`.",
+				Optional:    true,
+			},
+			"burst": schema.Int64Attribute{
+				Description: "Maximum number of requests sent to the Kubernetes API server in a burst, above the `qps` rate. Defaults to 10. Can be sourced from `KUBE_BURST`.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout of each request to the Kubernetes API server, as a duration like `30s` or `2m`. Zero, the default, means no timeout. Can be sourced from `KUBE_REQUEST_TIMEOUT`.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times a request to the Kubernetes API is retried after a transient error, like throttling or an unavailable control plane. Defaults to 5. Set to 0 to disable retries. Can be sourced from `KUBE_MAX_RETRIES`.",
+				Optional:    true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"exec": schema.ListNestedBlock{
@@ -176,6 +242,44 @@ func (p *KubernetesProvider) Schema(ctx context.Context, req provider.SchemaRequ
 					},
 				},
 			},
+			"impersonate": schema.ListNestedBlock{
+				Description: "Impersonate a user, and optionally groups, for all the requests to the Kubernetes API, like the `--as` and `--as-group` flags of `kubectl`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							Description: "Username to impersonate. Service accounts are impersonated as `system:serviceaccount:<namespace>:<name>`.",
+							Required:    true,
+						},
+						"uid": schema.StringAttribute{
+							Description: "UID of the user to impersonate.",
+							Optional:    true,
+						},
+						"groups": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "Groups to impersonate.",
+							Optional:    true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"extra": schema.ListNestedBlock{
+							Description: "Extra information about the user to impersonate, like the `--as-user-extra` flag of `kubectl`.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
+										Description: "Key of the extra information.",
+										Required:    true,
+									},
+									"values": schema.ListAttribute{
+										ElementType: types.StringType,
+										Description: "Values of the extra information.",
+										Required:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"experiments": schema.ListNestedBlock{
 				Description: "Enable and disable experimental features.",
 				NestedObject: schema.NestedBlockObject{
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	gversion "github.com/hashicorp/go-version"
@@ -21,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
 
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -101,6 +103,14 @@ func Provider() *schema.Provider {
 				Description:   "Path to the kube config file. Can be set with KUBE_CONFIG_PATH.",
 				ConflictsWith: []string{"config_paths"},
 			},
+			"config_raw": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("KUBE_CONFIG_RAW", ""),
+				Description:   "Content of a kube config file, as a string. Conflicts with `config_path` and `config_paths`, and the context to use is selected with the `config_context*` attributes. Can be sourced from `KUBE_CONFIG_RAW`, which takes precedence over the kube config paths.",
+				ConflictsWith: []string{"config_path", "config_paths"},
+			},
 			"config_context": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -169,6 +179,52 @@ func Provider() *schema.Provider {
 				},
 				Description: "",
 			},
+			"impersonate": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Impersonate a user, and optionally groups, for all the requests to the Kubernetes API, like the `--as` and `--as-group` flags of `kubectl`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Username to impersonate. Service accounts are impersonated as `system:serviceaccount:<namespace>:<name>`.",
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "UID of the user to impersonate.",
+						},
+						"groups": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Groups to impersonate.",
+						},
+						"extra": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Extra information about the user to impersonate, like the `--as-user-extra` flag of `kubectl`.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Key of the extra information.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Values of the extra information.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"experiments": {
 				Type:        schema.TypeList,
 				MaxItems:    1,
@@ -213,6 +269,67 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "List of Kubernetes metadata labels to ignore across all resources handled by this provider for situations where external systems are managing certain resource labels. Each item is a regular expression.",
 			},
+			"default_annotations": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateAnnotations,
+				Description:  "Map of annotations added to the metadata of every object managed by this provider. Annotations set on a resource take precedence over the ones set here.",
+			},
+			"default_labels": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ValidateFunc: validateLabels,
+				Description:  "Map of labels added to the metadata of every object managed by this provider. Labels set on a resource take precedence over the ones set here.",
+			},
+			"offline_schema_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Kubernetes minor version (e.g. `1.33`) of the OpenAPI schema bundled with the provider. When set, `kubernetes_manifest` resources of built-in kinds are planned against the bundled schema instead of contacting the API server. Can be sourced from `KUBE_OFFLINE_SCHEMA_VERSION`.",
+			},
+			"openapi_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a directory where OpenAPI documents retrieved from the API server are cached across provider runs. Cached documents are keyed by the API server address and version, and revalidated with the API server before use. Can be sourced from `KUBE_OPENAPI_CACHE_DIR`.",
+			},
+			"applyset_parent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_APPLYSET_PARENT", ""),
+				Description: "Reference to the Secret, in `<namespace>/<name>` format, that is the parent of the ApplySet that objects created by this provider are labelled as members of. See the `kubernetes_applyset` resource. Can be sourced from `KUBE_APPLYSET_PARENT`.",
+			},
+			"qps": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_QPS", nil),
+				Description: "Maximum number of requests per second to the Kubernetes API server, once the `burst` allowance is used up. Defaults to 5. A negative value disables client-side rate limiting. Can be sourced from `KUBE_QPS`.",
+			},
+			"burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KUBE_BURST", nil),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests sent to the Kubernetes API server in a burst, above the `qps` rate. Defaults to 10. Can be sourced from `KUBE_BURST`.",
+			},
+			"request_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_REQUEST_TIMEOUT", ""),
+				Description: "Timeout of each request to the Kubernetes API server, as a duration like `30s` or `2m`. Zero, the default, means no timeout. Can be sourced from `KUBE_REQUEST_TIMEOUT`.",
+			},
+			"max_retries": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				DefaultFunc: func() (interface{}, error) {
+					if v := os.Getenv("KUBE_MAX_RETRIES"); v != "" {
+						return strconv.Atoi(v)
+					}
+					return util.DefaultMaxRetries, nil
+				},
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request to the Kubernetes API is retried after a transient error, like throttling or an unavailable control plane. Defaults to 5. Set to 0 to disable retries. Can be sourced from `KUBE_MAX_RETRIES`.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -362,6 +479,14 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	for name, r := range p.ResourcesMap {
+		// token requests are not stored in the cluster, so they have no metadata to keep up to date
+		if name == "kubernetes_token_request_v1" {
+			continue
+		}
+		withEffectiveMetadata(r)
+	}
+
 	p.ConfigureProvider = func(ctx context.Context, req schema.ConfigureProviderRequest, res *schema.ConfigureProviderResponse) {
 		if req.DeferralAllowed && !req.ResourceData.GetRawConfig().IsWhollyKnown() {
 			res.Deferred = &schema.Deferred{
@@ -392,6 +517,10 @@ type providerMetadata struct {
 
 	IgnoreAnnotations []string
 	IgnoreLabels      []string
+	ApplySetID        string
+
+	DefaultAnnotations map[string]string
+	DefaultLabels      map[string]string
 }
 
 func (k providerMetadata) MainClientset() (*kubernetes.Clientset, error) {
@@ -475,6 +604,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 			return logging.NewSubsystemLoggingHTTPTransport("Kubernetes", rt)
 		}
 	}
+	// transient errors are retried around the logging transport, so that each attempt is logged
+	cfg.Wrap(util.RetryTransport(d.Get("max_retries").(int)))
 
 	ignoreAnnotations := []string{}
 	ignoreLabels := []string{}
@@ -486,12 +617,24 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 		ignoreLabels = expandStringSlice(v)
 	}
 
+	applySetID := ""
+	if v, ok := d.Get("applyset_parent").(string); ok && v != "" {
+		name, namespace, err := util.ParseApplySetParent(v)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		applySetID = util.ApplySetID(name, namespace)
+	}
+
 	m := providerMetadata{
 		config:              cfg,
 		mainClientset:       nil,
 		aggregatorClientset: nil,
 		IgnoreAnnotations:   ignoreAnnotations,
 		IgnoreLabels:        ignoreLabels,
+		ApplySetID:          applySetID,
+		DefaultAnnotations:  expandStringMap(d.Get("default_annotations").(map[string]interface{})),
+		DefaultLabels:       expandStringMap(d.Get("default_labels").(map[string]interface{})),
 	}
 	return m, diag.Diagnostics{}
 }
@@ -515,7 +658,9 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 		configPaths = filepath.SplitList(v)
 	}
 
-	if len(configPaths) > 0 {
+	configRaw, _ := d.Get("config_raw").(string)
+
+	if len(configPaths) > 0 && configRaw == "" {
 		expandedPaths := []string{}
 		for _, p := range configPaths {
 			path, err := homedir.Expand(p)
@@ -532,7 +677,9 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 		} else {
 			loader.Precedence = expandedPaths
 		}
+	}
 
+	if len(configPaths) > 0 || configRaw != "" {
 		ctxSuffix := "; default context"
 
 		kubectx, ctxOk := d.GetOk("config_context")
@@ -630,7 +777,49 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 		overrides.ClusterDefaults.ProxyURL = v.(string)
 	}
 
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	if v, ok := d.GetOk("impersonate"); ok {
+		if spec, ok := v.([]interface{})[0].(map[string]interface{}); ok {
+			overrides.AuthInfo.Impersonate = spec["user"].(string)
+			overrides.AuthInfo.ImpersonateUID = spec["uid"].(string)
+			overrides.AuthInfo.ImpersonateGroups = expandStringSlice(spec["groups"].([]interface{}))
+			for _, e := range spec["extra"].([]interface{}) {
+				extra, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if overrides.AuthInfo.ImpersonateUserExtra == nil {
+					overrides.AuthInfo.ImpersonateUserExtra = map[string][]string{}
+				}
+				key := extra["key"].(string)
+				overrides.AuthInfo.ImpersonateUserExtra[key] = append(overrides.AuthInfo.ImpersonateUserExtra[key], expandStringSlice(extra["values"].([]interface{}))...)
+			}
+		} else {
+			nd := diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Failed to parse 'impersonate' provider configuration",
+				AttributePath: cty.Path{}.IndexString("impersonate"),
+			}
+			return nil, append(diags, nd)
+		}
+	}
+
+	var cc clientcmd.ClientConfig
+	if configRaw != "" {
+		log.Printf("[DEBUG] Using kubeconfig from config_raw")
+		rawConfig, err := clientcmd.Load([]byte(configRaw))
+		if err != nil {
+			nd := diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Failed to parse value for config_raw",
+				Detail:        err.Error(),
+				AttributePath: cty.Path{}.IndexString("config_raw"),
+			}
+			return nil, append(diags, nd)
+		}
+		cc = clientcmd.NewNonInteractiveClientConfig(*rawConfig, overrides.CurrentContext, overrides, nil)
+	} else {
+		cc = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	}
 	cfg, err := cc.ClientConfig()
 	if err != nil {
 		nd := diag.Diagnostic{
@@ -642,6 +831,29 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 		return nil, append(diags, nd)
 	}
 
+	if v, ok := d.GetOk("qps"); ok {
+		cfg.QPS = float32(v.(float64))
+	}
+	if v, ok := d.GetOk("burst"); ok {
+		cfg.Burst = v.(int)
+	}
+	if v, ok := d.GetOk("request_timeout"); ok {
+		timeout, err := time.ParseDuration(v.(string))
+		if err == nil && timeout < 0 {
+			err = fmt.Errorf("the timeout must not be negative")
+		}
+		if err != nil {
+			nd := diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Failed to parse value for request_timeout: %s", v.(string)),
+				Detail:        err.Error(),
+				AttributePath: cty.Path{}.IndexString("request_timeout"),
+			}
+			return nil, append(diags, nd)
+		}
+		cfg.Timeout = timeout
+	}
+
 	return cfg, diags
 }
 
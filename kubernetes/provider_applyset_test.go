@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func TestAccKubernetesApplySet_update(t *testing.T) {
+	var conf batchv1.CronJob
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_cron_job_v1.test"
+	applySetID := util.ApplySetID(name, "default")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.25.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesCronJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesApplySetConfig(name, "1 0 * * *"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCronJobV1Exists(resourceName, &conf),
+					testAccCheckKubernetesApplySetLabel(&conf.ObjectMeta.Labels, applySetID),
+				),
+			},
+			// the cron job is updated in place, and is still labelled as a member of the ApplySet
+			{
+				Config: testAccKubernetesApplySetConfig(name, "2 0 * * *"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCronJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.schedule", "2 0 * * *"),
+					testAccCheckKubernetesApplySetLabel(&conf.ObjectMeta.Labels, applySetID),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesApplySetLabel(labels *map[string]string, id string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v := (*labels)[util.ApplySetPartOfLabel]; v != id {
+			return fmt.Errorf("expected the object to be labelled as a member of the ApplySet %q, got labels: %v", id, *labels)
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesApplySetConfig(name, schedule string) string {
+	return fmt.Sprintf(`provider "kubernetes" {
+  applyset_parent = "default/%s"
+}
+
+resource "kubernetes_cron_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    schedule = "%s"
+    suspend  = true
+    job_template {
+      metadata {}
+      spec {
+        template {
+          metadata {}
+          spec {
+            container {
+              name    = "hello"
+              image   = "%s"
+              command = ["echo", "'hello'"]
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`, name, name, schedule, busyboxImage)
+}
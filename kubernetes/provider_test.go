@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
@@ -106,6 +107,112 @@ func TestProvider_configure_paths(t *testing.T) {
 	}
 }
 
+func TestProvider_configure_requestOptions(t *testing.T) {
+	ctx := context.TODO()
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	os.Setenv("KUBE_CONFIG_PATH", "test-fixtures/kube-config.yaml")
+	os.Setenv("KUBE_CTX", "gcp")
+	t.Setenv("KUBE_QPS", "50")
+
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"burst":           100,
+		"request_timeout": "30s",
+	})
+	p := Provider()
+	diags := p.Configure(ctx, rc)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	cfg := p.Meta().(providerMetadata).config
+	if cfg.QPS != 50 || cfg.Burst != 100 || cfg.Timeout != 30*time.Second {
+		t.Fatalf("unexpected request options: qps %v, burst %d, timeout %s", cfg.QPS, cfg.Burst, cfg.Timeout)
+	}
+
+	rc = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"request_timeout": "30",
+	})
+	diags = Provider().Configure(ctx, rc)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a timeout without unit")
+	}
+}
+
+func TestProvider_configure_impersonate(t *testing.T) {
+	ctx := context.TODO()
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	os.Setenv("KUBE_CONFIG_PATH", "test-fixtures/kube-config.yaml")
+	os.Setenv("KUBE_CTX", "gcp")
+
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"impersonate": []interface{}{
+			map[string]interface{}{
+				"user":   "system:serviceaccount:tenant:deployer",
+				"groups": []interface{}{"system:serviceaccounts", "tenants"},
+				"extra": []interface{}{
+					map[string]interface{}{
+						"key":    "scopes",
+						"values": []interface{}{"view", "edit"},
+					},
+				},
+			},
+		},
+	})
+	p := Provider()
+	diags := p.Configure(ctx, rc)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	imp := p.Meta().(providerMetadata).config.Impersonate
+	if imp.UserName != "system:serviceaccount:tenant:deployer" {
+		t.Fatalf("unexpected impersonated user: %q", imp.UserName)
+	}
+	if strings.Join(imp.Groups, ",") != "system:serviceaccounts,tenants" {
+		t.Fatalf("unexpected impersonated groups: %v", imp.Groups)
+	}
+	if strings.Join(imp.Extra["scopes"], ",") != "view,edit" {
+		t.Fatalf("unexpected impersonated extra: %v", imp.Extra)
+	}
+}
+
+func TestProvider_configure_raw(t *testing.T) {
+	ctx := context.TODO()
+	resetEnv := unsetEnv(t)
+	defer resetEnv()
+
+	raw, err := os.ReadFile("test-fixtures/kube-config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"config_raw":     string(raw),
+		"config_context": "oidc",
+	})
+	p := Provider()
+	diags := p.Configure(ctx, rc)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	cfg := p.Meta().(providerMetadata).config
+	if cfg.Host != "https://127.0.0.1" {
+		t.Fatalf("unexpected host: %q", cfg.Host)
+	}
+	if cfg.AuthProvider == nil || cfg.AuthProvider.Name != "oidc" {
+		t.Fatalf("expected the user of the oidc context, got: %v", cfg.AuthProvider)
+	}
+
+	rc = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"config_raw": "clusters: {",
+	})
+	diags = Provider().Configure(ctx, rc)
+	if !diags.HasError() {
+		t.Fatal("expected an error for an invalid kube config")
+	}
+}
+
 func unsetEnv(t *testing.T) func() {
 	e := getEnv()
 
@@ -113,6 +113,7 @@ func resourceKubernetesAPIServiceV1Create(ctx context.Context, d *schema.Resourc
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	svc := v1.APIService{
 		ObjectMeta: metadata,
 		Spec:       expandAPIServiceV1Spec(d.Get("spec").([]interface{})),
@@ -173,7 +174,7 @@ func resourceKubernetesAPIServiceV1Update(ctx context.Context, d *schema.Resourc
 	}
 
 	name := d.Id()
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec",
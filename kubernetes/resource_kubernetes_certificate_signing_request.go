@@ -96,6 +96,7 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandCertificateSigningRequestSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
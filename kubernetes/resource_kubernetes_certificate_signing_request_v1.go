@@ -100,6 +100,7 @@ func resourceKubernetesCertificateSigningRequestV1Create(ctx context.Context, d
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec := expandCertificateSigningRequestV1Spec(d.Get("spec").([]interface{}))
 
 	csr := certificates.CertificateSigningRequest{
@@ -59,6 +59,7 @@ func resourceKubernetesClusterRoleBindingV1Create(ctx context.Context, d *schema
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	binding := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRBACRoleRef(d.Get("role_ref").([]interface{})),
@@ -133,7 +134,7 @@ func resourceKubernetesClusterRoleBindingV1Update(ctx context.Context, d *schema
 
 	name := d.Id()
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("subject") {
 		diffOps := patchRbacSubject(d)
 		ops = append(ops, diffOps...)
@@ -68,6 +68,7 @@ func resourceKubernetesClusterRoleV1Create(ctx context.Context, d *schema.Resour
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	cRole := rbacv1.ClusterRole{
 		ObjectMeta: metadata,
 		Rules:      expandClusterRoleRules(d.Get("rule").([]interface{})),
@@ -95,7 +96,7 @@ func resourceKubernetesClusterRoleV1Update(ctx context.Context, d *schema.Resour
 	}
 
 	name := d.Id()
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("rule") {
 		diffOps := patchRbacRule(d)
 		ops = append(ops, diffOps...)
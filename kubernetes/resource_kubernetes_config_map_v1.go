@@ -81,6 +81,7 @@ func resourceKubernetesConfigMapV1Create(ctx context.Context, d *schema.Resource
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	cfgMap := corev1.ConfigMap{
 		ObjectMeta: metadata,
 		BinaryData: expandBase64MapToByteMap(d.Get("binary_data").(map[string]interface{})),
@@ -157,7 +158,7 @@ func resourceKubernetesConfigMapV1Update(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("binary_data") {
 		oldV, newV := d.GetChange("binary_data")
 		diffOps := diffStringMap("/binaryData/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
@@ -17,6 +17,7 @@ import (
 
 	batch "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kretry "k8s.io/client-go/util/retry"
 )
 
 func resourceKubernetesCronJobV1(deprecationMessage string) *schema.Resource {
@@ -56,6 +57,7 @@ func resourceKubernetesCronJobV1Create(ctx context.Context, d *schema.ResourceDa
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandCronJobSpecV1(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -90,6 +92,7 @@ func resourceKubernetesCronJobV1Update(ctx context.Context, d *schema.ResourceDa
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandCronJobSpecV1(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -102,7 +105,17 @@ func resourceKubernetesCronJobV1Update(ctx context.Context, d *schema.ResourceDa
 
 	log.Printf("[INFO] Updating cron job %s: %s", d.Id(), cronjob)
 
-	out, err := conn.BatchV1().CronJobs(namespace).Update(ctx, cronjob, metav1.UpdateOptions{})
+	// the object is replaced as a whole, so it is read again to update its latest version on conflicts
+	var out *batch.CronJob
+	err = kretry.RetryOnConflict(kretry.DefaultRetry, func() error {
+		live, err := conn.BatchV1().CronJobs(namespace).Get(ctx, cronjob.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cronjob.ResourceVersion = live.ResourceVersion
+		out, err = conn.BatchV1().CronJobs(namespace).Update(ctx, cronjob, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
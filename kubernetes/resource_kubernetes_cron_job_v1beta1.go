@@ -16,6 +16,7 @@ import (
 	"k8s.io/api/batch/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kretry "k8s.io/client-go/util/retry"
 )
 
 func resourceKubernetesCronJobV1Beta1(deprecationMessage string) *schema.Resource {
@@ -65,6 +66,7 @@ func resourceKubernetesCronJobV1Beta1Create(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandCronJobSpecV1Beta1(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -100,6 +102,7 @@ func resourceKubernetesCronJobV1Beta1Update(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandCronJobSpecV1Beta1(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -113,7 +116,17 @@ func resourceKubernetesCronJobV1Beta1Update(ctx context.Context, d *schema.Resou
 
 	log.Printf("[INFO] Updating cron job %s: %s", d.Id(), cronjob)
 
-	out, err := conn.BatchV1beta1().CronJobs(namespace).Update(ctx, cronjob, metav1.UpdateOptions{})
+	// the object is replaced as a whole, so it is read again to update its latest version on conflicts
+	var out *v1beta1.CronJob
+	err = kretry.RetryOnConflict(kretry.DefaultRetry, func() error {
+		live, err := conn.BatchV1beta1().CronJobs(namespace).Get(ctx, cronjob.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cronjob.ResourceVersion = live.ResourceVersion
+		out, err = conn.BatchV1beta1().CronJobs(namespace).Update(ctx, cronjob, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -82,6 +82,7 @@ func resourceKubernetesCSIDriverV1Create(ctx context.Context, d *schema.Resource
 		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
 		Spec:       expandCSIDriverV1Spec(d.Get("spec").([]interface{})),
 	}
+	applyProviderMetadata(&CSIDriver.ObjectMeta, meta)
 
 	log.Printf("[INFO] Creating new CSIDriver: %#v", CSIDriver)
 	out, err := conn.StorageV1().CSIDrivers().Create(ctx, &CSIDriver, metav1.CreateOptions{})
@@ -138,7 +139,7 @@ func resourceKubernetesCSIDriverV1Update(ctx context.Context, d *schema.Resource
 	}
 
 	name := d.Id()
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		diffOps := patchCSIDriverV1Spec("spec.0.", "/spec", d)
 		ops = append(ops, *diffOps...)
@@ -78,6 +78,7 @@ func resourceKubernetesCSIDriverV1Beta1Create(ctx context.Context, d *schema.Res
 		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
 		Spec:       expandCSIDriverSpec(d.Get("spec").([]interface{})),
 	}
+	applyProviderMetadata(&CSIDriver.ObjectMeta, meta)
 
 	log.Printf("[INFO] Creating new CSIDriver: %#v", CSIDriver)
 	out, err := conn.StorageV1beta1().CSIDrivers().Create(ctx, &CSIDriver, metav1.CreateOptions{})
@@ -134,7 +135,7 @@ func resourceKubernetesCSIDriverV1Beta1Update(ctx context.Context, d *schema.Res
 	}
 
 	name := d.Id()
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		diffOps := patchCSIDriverSpec("spec.0.", "/spec", d)
 		ops = append(ops, *diffOps...)
@@ -154,6 +154,7 @@ func resourceKubernetesDaemonSetV1Create(ctx context.Context, d *schema.Resource
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandDaemonSetSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -197,7 +198,7 @@ func resourceKubernetesDaemonSetV1Update(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("spec") {
 		spec, err := expandDaemonSetSpec(d.Get("spec").([]interface{}))
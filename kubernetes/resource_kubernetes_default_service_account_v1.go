@@ -44,8 +44,10 @@ func resourceKubernetesDefaultServiceAccountV1Create(ctx context.Context, d *sch
 	svcAcc := corev1.ServiceAccount{ObjectMeta: metadata}
 
 	log.Printf("[INFO] Checking for default service account existence: %s", metadata.Namespace)
+	var live *corev1.ServiceAccount
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-		_, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+		var err error
+		live, err = conn.CoreV1().ServiceAccounts(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 		if err != nil {
 			if errors.IsNotFound(err) {
 				log.Printf("[INFO] Default service account does not exist, will retry: %s", metadata.Namespace)
@@ -68,7 +70,8 @@ func resourceKubernetesDefaultServiceAccountV1Create(ctx context.Context, d *sch
 	}
 	d.Set("default_secret_name", secret.Name)
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	// the default service account already exists, so the annotations and labels it has are kept
+	ops := adoptMetadata("/metadata/", live.ObjectMeta, d, meta)
 	if d.HasChange("image_pull_secret") {
 		v := d.Get("image_pull_secret").(*schema.Set).List()
 		ops = append(ops, &ReplaceOperation{
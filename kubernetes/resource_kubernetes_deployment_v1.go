@@ -237,6 +237,7 @@ func resourceKubernetesDeploymentV1Create(ctx context.Context, d *schema.Resourc
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -282,7 +283,7 @@ func resourceKubernetesDeploymentV1Update(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("spec") {
 		spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
@@ -52,6 +52,7 @@ func resourceKubernetesEndpointsV1Create(ctx context.Context, d *schema.Resource
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	ep := api.Endpoints{
 		ObjectMeta: metadata,
 		Subsets:    expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
@@ -119,7 +120,7 @@ func resourceKubernetesEndpointsV1Update(ctx context.Context, d *schema.Resource
 		return diag.Errorf("Failed to update endpoints because: %s", err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("subset") {
 		subsets := expandEndpointsSubsets(d.Get("subset").(*schema.Set))
 		ops = append(ops, &ReplaceOperation{
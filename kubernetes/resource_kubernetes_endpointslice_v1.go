@@ -59,6 +59,7 @@ func resourceKubernetesEndpointSliceV1Create(ctx context.Context, d *schema.Reso
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	endpoint_slice := api.EndpointSlice{
 		ObjectMeta:  metadata,
 		AddressType: api.AddressType(d.Get("address_type").(string)),
@@ -132,7 +133,7 @@ func resourceKubernetesEndpointSliceV1Update(ctx context.Context, d *schema.Reso
 		return diag.Errorf("Failed to update endpointSlice because: %s", err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("address_type") {
 		address_type := d.Get("address_type").(string)
 		ops = append(ops, &ReplaceOperation{
@@ -91,6 +91,7 @@ func resourceKubernetesHorizontalPodAutoscalerV1Create(ctx context.Context, d *s
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandHorizontalPodAutoscalerSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -165,7 +166,7 @@ func resourceKubernetesHorizontalPodAutoscalerV1Update(ctx context.Context, d *s
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		diffOps := patchHorizontalPodAutoscalerSpec("spec.0.", "/spec", d)
 		ops = append(ops, diffOps...)
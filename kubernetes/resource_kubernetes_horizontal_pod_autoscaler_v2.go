@@ -39,6 +39,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Create(ctx context.Context, d *s
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandHorizontalPodAutoscalerV2Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -114,7 +115,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Update(ctx context.Context, d *s
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		diffOps := patchHorizontalPodAutoscalerV2Spec("spec.0.", "/spec", d)
 		ops = append(ops, diffOps...)
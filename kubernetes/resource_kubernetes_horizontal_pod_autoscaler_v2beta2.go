@@ -38,6 +38,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Create(ctx context.Context,
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandHorizontalPodAutoscalerV2Beta2Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -110,7 +111,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Update(ctx context.Context,
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		diffOps := patchHorizontalPodAutoscalerV2Beta2Spec("spec.0.", "/spec", d)
 		ops = append(ops, diffOps...)
@@ -16,6 +16,7 @@ import (
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kretry "k8s.io/client-go/util/retry"
 )
 
 func resourceKubernetesIngressClassV1(deprecationMessage string) *schema.Resource {
@@ -102,6 +103,7 @@ func resourceKubernetesIngressClassV1Create(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	ing := &networking.IngressClass{
 		Spec: expandIngressClassV1Spec(d.Get("spec").([]interface{})),
 	}
@@ -172,6 +174,7 @@ func resourceKubernetesIngressClassV1Update(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec := expandIngressClassV1Spec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
@@ -183,7 +186,17 @@ func resourceKubernetesIngressClassV1Update(ctx context.Context, d *schema.Resou
 		Spec:       spec,
 	}
 
-	out, err := conn.NetworkingV1().IngressClasses().Update(ctx, ingressClass, metav1.UpdateOptions{})
+	// the object is replaced as a whole, so it is read again to update its latest version on conflicts
+	var out *networking.IngressClass
+	err = kretry.RetryOnConflict(kretry.DefaultRetry, func() error {
+		live, err := conn.NetworkingV1().IngressClasses().Get(ctx, ingressClass.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		ingressClass.ResourceVersion = live.ResourceVersion
+		out, err = conn.NetworkingV1().IngressClasses().Update(ctx, ingressClass, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return diag.Errorf("Failed to update Ingress Class %s because: %s", buildId(ingressClass.ObjectMeta), err)
 	}
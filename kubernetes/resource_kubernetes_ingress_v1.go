@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kretry "k8s.io/client-go/util/retry"
 )
 
 func resourceKubernetesIngressV1(deprecationMessage string) *schema.Resource {
@@ -183,6 +184,7 @@ func resourceKubernetesIngressV1Create(ctx context.Context, d *schema.ResourceDa
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	ing := &networking.Ingress{
 		Spec: expandIngressV1Spec(d.Get("spec").([]interface{})),
 	}
@@ -295,6 +297,7 @@ func resourceKubernetesIngressV1Update(ctx context.Context, d *schema.ResourceDa
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec := expandIngressV1Spec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
@@ -306,7 +309,17 @@ func resourceKubernetesIngressV1Update(ctx context.Context, d *schema.ResourceDa
 		Spec:       spec,
 	}
 
-	out, err := conn.NetworkingV1().Ingresses(namespace).Update(ctx, ingress, metav1.UpdateOptions{})
+	// the object is replaced as a whole, so it is read again to update its latest version on conflicts
+	var out *networking.Ingress
+	err = kretry.RetryOnConflict(kretry.DefaultRetry, func() error {
+		live, err := conn.NetworkingV1().Ingresses(namespace).Get(ctx, ingress.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		ingress.ResourceVersion = live.ResourceVersion
+		out, err = conn.NetworkingV1().Ingresses(namespace).Update(ctx, ingress, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return diag.Errorf("Failed to update Ingress %s because: %s", buildId(ingress.ObjectMeta), err)
 	}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kretry "k8s.io/client-go/util/retry"
 )
 
 func resourceKubernetesIngressV1Beta1(deprecationMessage string) *schema.Resource {
@@ -173,6 +174,7 @@ func resourceKubernetesIngressV1Beta1Create(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	ing := &v1beta1.Ingress{
 		Spec: expandIngressSpec(d.Get("spec").([]interface{})),
 	}
@@ -281,6 +283,7 @@ func resourceKubernetesIngressV1Beta1Update(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec := expandIngressSpec(d.Get("spec").([]interface{}))
 
 	if metadata.Namespace == "" {
@@ -292,7 +295,17 @@ func resourceKubernetesIngressV1Beta1Update(ctx context.Context, d *schema.Resou
 		Spec:       spec,
 	}
 
-	out, err := conn.ExtensionsV1beta1().Ingresses(namespace).Update(ctx, ingress, metav1.UpdateOptions{})
+	// the object is replaced as a whole, so it is read again to update its latest version on conflicts
+	var out *v1beta1.Ingress
+	err = kretry.RetryOnConflict(kretry.DefaultRetry, func() error {
+		live, err := conn.ExtensionsV1beta1().Ingresses(namespace).Get(ctx, ingress.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		ingress.ResourceVersion = live.ResourceVersion
+		out, err = conn.ExtensionsV1beta1().Ingresses(namespace).Update(ctx, ingress, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return diag.Errorf("Failed to update Ingress %s because: %s", buildId(ingress.ObjectMeta), err)
 	}
@@ -78,6 +78,7 @@ func resourceKubernetesJobV1Create(ctx context.Context, d *schema.ResourceData,
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandJobV1Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -184,7 +185,7 @@ func resourceKubernetesJobV1Update(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("spec") {
 		specOps := patchJobV1Spec("/spec", "spec.0.", d)
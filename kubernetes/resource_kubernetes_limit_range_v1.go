@@ -91,6 +91,7 @@ func resourceKubernetesLimitRangeV1Create(ctx context.Context, d *schema.Resourc
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandLimitRangeSpec(d.Get("spec").([]interface{}), d.IsNewResource())
 	if err != nil {
 		return diag.FromErr(err)
@@ -159,7 +160,7 @@ func resourceKubernetesLimitRangeV1Update(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		spec, err := expandLimitRangeSpec(d.Get("spec").([]interface{}), d.IsNewResource())
 		if err != nil {
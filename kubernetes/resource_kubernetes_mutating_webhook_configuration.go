@@ -154,6 +154,7 @@ func resourceKubernetesMutatingWebhookConfigurationCreate(ctx context.Context, d
 		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
 		Webhooks:   expandMutatingWebhooks(d.Get("webhook").([]interface{})),
 	}
+	applyProviderMetadata(&cfg.ObjectMeta, meta)
 
 	log.Printf("[INFO] Creating new MutatingWebhookConfiguration: %#v", cfg)
 
@@ -239,7 +240,7 @@ func resourceKubernetesMutatingWebhookConfigurationUpdate(ctx context.Context, d
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("webhook") {
 		op := &ReplaceOperation{
@@ -151,6 +151,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Create(ctx context.Context,
 		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
 		Webhooks:   expandMutatingWebhooks(d.Get("webhook").([]interface{})),
 	}
+	applyProviderMetadata(&cfg.ObjectMeta, meta)
 
 	log.Printf("[INFO] Creating new MutatingWebhookConfiguration: %#v", cfg)
 
@@ -212,7 +213,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Update(ctx context.Context,
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("webhook") {
 		op := &ReplaceOperation{
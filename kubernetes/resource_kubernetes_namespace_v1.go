@@ -54,6 +54,7 @@ func resourceKubernetesNamespaceV1Create(ctx context.Context, d *schema.Resource
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	namespace := corev1.Namespace{
 		ObjectMeta: metadata,
 	}
@@ -129,7 +130,7 @@ func resourceKubernetesNamespaceV1Update(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
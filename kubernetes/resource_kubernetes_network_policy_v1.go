@@ -257,6 +257,7 @@ func resourceKubernetesNetworkPolicyV1Create(ctx context.Context, d *schema.Reso
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandNetworkPolicyV1Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -332,7 +333,7 @@ func resourceKubernetesNetworkPolicyV1Update(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		diffOps, err := patchNetworkPolicyV1Spec("spec.0.", "/spec", d)
 		if err != nil {
@@ -104,6 +104,7 @@ func resourceKubernetesPersistentVolumeClaimV1Create(ctx context.Context, d *sch
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	applyProviderMetadata(&claim.ObjectMeta, meta)
 	log.Printf("[INFO] Creating new persistent volume claim: %#v", claim)
 	out, err := conn.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
 	if err != nil {
@@ -207,7 +208,7 @@ func resourceKubernetesPersistentVolumeClaimV1Update(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	// spec.resources.requests is the only editable field in Spec.
 	if d.HasChange("spec.0.resources.0.requests") {
 		r := d.Get("spec.0.resources.0.requests").(map[string]interface{})
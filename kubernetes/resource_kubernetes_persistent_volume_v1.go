@@ -215,6 +215,7 @@ func resourceKubernetesPersistentVolumeV1Create(ctx context.Context, d *schema.R
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandPersistentVolumeSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -309,7 +310,7 @@ func resourceKubernetesPersistentVolumeV1Update(ctx context.Context, d *schema.R
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		specOps, err := patchPersistentVolumeSpec("/spec", "spec", d)
 		if err != nil {
@@ -91,7 +91,7 @@ func resourceKubernetesPodDisruptionBudgetUpdate(ctx context.Context, d *schema.
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
@@ -116,6 +116,7 @@ func resourceKubernetesPodDisruptionBudgetCreate(ctx context.Context, d *schema.
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandPodDisruptionBudgetSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
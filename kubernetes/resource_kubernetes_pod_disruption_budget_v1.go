@@ -90,7 +90,7 @@ func resourceKubernetesPodDisruptionBudgetV1Update(ctx context.Context, d *schem
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
@@ -115,6 +115,7 @@ func resourceKubernetesPodDisruptionBudgetV1Create(ctx context.Context, d *schem
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandPodDisruptionBudgetV1Spec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
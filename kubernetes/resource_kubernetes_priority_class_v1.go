@@ -72,6 +72,7 @@ func resourceKubernetesPriorityClassV1Create(ctx context.Context, d *schema.Reso
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	value := d.Get("value").(int)
 	description := d.Get("description").(string)
 	globalDefault := d.Get("global_default").(bool)
@@ -156,7 +157,7 @@ func resourceKubernetesPriorityClassV1Update(ctx context.Context, d *schema.Reso
 
 	name := d.Id()
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("description") {
 		description := d.Get("description").(string)
@@ -119,6 +119,7 @@ func resourceKubernetesReplicationControllerV1Create(ctx context.Context, d *sch
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 
 	spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
 	if err != nil {
@@ -211,7 +212,7 @@ func resourceKubernetesReplicationControllerV1Update(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("spec") {
 		spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
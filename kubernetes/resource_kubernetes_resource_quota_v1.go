@@ -116,6 +116,7 @@ func resourceKubernetesResourceQuotaV1Create(ctx context.Context, d *schema.Reso
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandResourceQuotaSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -209,7 +210,7 @@ func resourceKubernetesResourceQuotaV1Update(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	var spec *api.ResourceQuotaSpec
 	waitForChangedSpec := false
 	if d.HasChange("spec") {
@@ -59,6 +59,7 @@ func resourceKubernetesRoleBindingV1Create(ctx context.Context, d *schema.Resour
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	binding := &rbacv1.RoleBinding{
 		ObjectMeta: metadata,
 		RoleRef:    expandRBACRoleRef(d.Get("role_ref").([]interface{})),
@@ -138,7 +139,7 @@ func resourceKubernetesRoleBindingV1Update(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("subject") {
 		diffOps := patchRbacSubject(d)
 		ops = append(ops, diffOps...)
@@ -78,6 +78,7 @@ func resourceKubernetesRoleV1Create(ctx context.Context, d *schema.ResourceData,
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	rules := expandRules(d.Get("rule").([]interface{}))
 
 	role := rbacv1.Role{
@@ -150,7 +151,7 @@ func resourceKubernetesRoleV1Update(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("rule") {
 		rules := expandRules(d.Get("rule").([]interface{}))
 
@@ -53,6 +53,7 @@ func resourceKubernetesRuntimeClassV1Create(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 
 	runtimeClass := nodev1.RuntimeClass{
 		ObjectMeta: metadata,
@@ -116,7 +117,7 @@ func resourceKubernetesRuntimeClassV1Update(ctx context.Context, d *schema.Resou
 
 	name := d.Id()
 
-	patch := patchMetadata("metadata.0.", "/metadata/", d)
+	patch := patchMetadata("/metadata/", d, meta)
 
 	data, err := patch.MarshalJSON()
 	if err != nil {
@@ -143,6 +143,7 @@ func resourceKubernetesSecretV1Create(ctx context.Context, d *schema.ResourceDat
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	secret := corev1.Secret{
 		ObjectMeta: metadata,
 	}
@@ -304,7 +305,7 @@ func resourceKubernetesSecretV1Update(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	newData := map[string]interface{}{}
 	updateData := false
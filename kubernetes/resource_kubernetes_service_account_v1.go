@@ -89,6 +89,7 @@ func resourceKubernetesServiceAccountV1Create(ctx context.Context, d *schema.Res
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	svcAcc := corev1.ServiceAccount{
 		AutomountServiceAccountToken: ptr.To(d.Get("automount_service_account_token").(bool)),
 		ObjectMeta:                   metadata,
@@ -326,7 +327,7 @@ func resourceKubernetesServiceAccountV1Update(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("image_pull_secret") {
 		v := d.Get("image_pull_secret").(*schema.Set).List()
 		ops = append(ops, &ReplaceOperation{
@@ -344,6 +344,7 @@ func resourceKubernetesServiceV1Create(ctx context.Context, d *schema.ResourceDa
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	svc := corev1.Service{
 		ObjectMeta: metadata,
 		Spec:       expandServiceSpec(d.Get("spec").([]interface{})),
@@ -453,7 +454,7 @@ func resourceKubernetesServiceV1Update(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 	if d.HasChange("spec") {
 		serverVersion, err := getServerVersion(conn)
 		if err != nil {
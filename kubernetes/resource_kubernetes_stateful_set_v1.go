@@ -82,6 +82,7 @@ func resourceKubernetesStatefulSetV1Create(ctx context.Context, d *schema.Resour
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
@@ -201,7 +202,7 @@ func resourceKubernetesStatefulSetV1Update(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.Errorf("Error parsing resource ID: %#v", err)
 	}
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("spec") {
 		log.Println("[TRACE] StatefulSet.Spec has changes")
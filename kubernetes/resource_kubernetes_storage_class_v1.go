@@ -125,6 +125,7 @@ func resourceKubernetesStorageClassV1Create(ctx context.Context, d *schema.Resou
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, meta)
 	reclaimPolicy := v1.PersistentVolumeReclaimPolicy(d.Get("reclaim_policy").(string))
 	volumeBindingMode := api.VolumeBindingMode(d.Get("volume_binding_mode").(string))
 	allowVolumeExpansion := d.Get("allow_volume_expansion").(bool)
@@ -206,7 +207,7 @@ func resourceKubernetesStorageClassV1Update(ctx context.Context, d *schema.Resou
 	}
 
 	name := d.Id()
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("allow_volume_expansion") {
 		newVal := d.Get("allow_volume_expansion").(bool)
@@ -144,6 +144,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Create(ctx context.Contex
 		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
 		Webhooks:   expandValidatingWebhooks(d.Get("webhook").([]interface{})),
 	}
+	applyProviderMetadata(&cfg.ObjectMeta, meta)
 
 	log.Printf("[INFO] Creating new ValidatingWebhookConfiguration: %#v", cfg)
 
@@ -234,7 +235,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Update(ctx context.Contex
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("webhook") {
 		op := &ReplaceOperation{
@@ -143,6 +143,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Create(ctx context.C
 		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
 		Webhooks:   expandValidatingWebhooks(d.Get("webhook").([]interface{})),
 	}
+	applyProviderMetadata(&cfg.ObjectMeta, meta)
 
 	log.Printf("[INFO] Creating new ValidatingWebhookConfiguration: %#v", cfg)
 
@@ -228,7 +229,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Update(ctx context.C
 		return diag.FromErr(err)
 	}
 
-	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	ops := patchMetadata("/metadata/", d, meta)
 
 	if d.HasChange("webhook") {
 		op := &ReplaceOperation{
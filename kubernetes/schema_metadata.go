@@ -4,9 +4,13 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
 )
 
 func metadataFields(objectName string) map[string]*schema.Schema {
@@ -117,3 +121,66 @@ func namespacedMetadataSchemaIsTemplate(objectName string, generatableName, isTe
 		},
 	}
 }
+
+// withEffectiveMetadata adds the effective annotations and labels to a resource that can update the metadata
+// of its object in place. They hold the annotations and labels of the resource together with the default
+// annotations and labels of the provider, so that the plan shows the complete set applied to the object.
+func withEffectiveMetadata(r *schema.Resource) {
+	m, ok := r.Schema["metadata"]
+	if !ok || m.ForceNew || (r.Update == nil && r.UpdateContext == nil && r.UpdateWithoutTimeout == nil) {
+		return
+	}
+	if e, ok := m.Elem.(*schema.Resource); !ok || e.Schema["uid"] == nil {
+		return
+	}
+	r.Schema["effective_annotations"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "All of the annotations of the object, including the default annotations configured on the provider.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+	r.Schema["effective_labels"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "All of the labels of the object, including the default labels configured on the provider.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+		return effectiveMetadataDiff(ctx, d, meta)
+	}
+}
+
+// effectiveMetadataDiff plans the effective annotations and labels of a resource from its configuration
+// and the default annotations and labels of the provider
+func effectiveMetadataDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	pm, ok := meta.(providerMetadata)
+	if !ok {
+		return nil
+	}
+	for key, defaults := range map[string]map[string]string{
+		"annotations": pm.DefaultAnnotations,
+		"labels":      pm.DefaultLabels,
+	} {
+		if !d.NewValueKnown("metadata.0." + key) {
+			if err := d.SetNewComputed("effective_" + key); err != nil {
+				return err
+			}
+			continue
+		}
+		v := util.MergeDefaults(expandStringMap(d.Get("metadata.0."+key).(map[string]interface{})), defaults)
+		if d.Id() != "" && reflect.DeepEqual(v, expandStringMap(d.Get("effective_"+key).(map[string]interface{}))) {
+			continue
+		}
+		if err := d.SetNew("effective_"+key, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -42,7 +42,7 @@ func flattenCronJobSpecV1Beta1(in v1beta1.CronJobSpec, d *schema.ResourceData, m
 func flattenJobTemplate(in v1beta1.JobTemplateSpec, d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
-	att["metadata"] = flattenMetadata(in.ObjectMeta, d, meta)
+	att["metadata"] = flattenTemplateMetadata(in.ObjectMeta, d, meta)
 
 	jobSpec, err := flattenJobV1Spec(in.Spec, d, meta, "spec.0.job_template.0.spec.0.template.0.")
 	if err != nil {
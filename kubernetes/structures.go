@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,21 +69,94 @@ func expandMetadata(in []interface{}) metav1.ObjectMeta {
 	return meta
 }
 
-func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
+// applyProviderMetadata adds the metadata configured at the provider level to the metadata of a new object.
+// The default labels and annotations of the provider are added, unless the object sets them itself.
+// When the provider is configured with an ApplySet parent, the object is labelled as a member of the ApplySet.
+func applyProviderMetadata(meta *metav1.ObjectMeta, providerMeta interface{}) {
+	pm, ok := providerMeta.(providerMetadata)
+	if !ok {
+		return
+	}
+	meta.Annotations = util.MergeDefaults(meta.Annotations, pm.DefaultAnnotations)
+	meta.Labels = util.MergeDefaults(meta.Labels, pm.DefaultLabels)
+	if pm.ApplySetID != "" {
+		if meta.Labels == nil {
+			meta.Labels = make(map[string]string)
+		}
+		meta.Labels[util.ApplySetPartOfLabel] = pm.ApplySetID
+	}
+}
+
+// patchMetadata returns the operations to patch the annotations and labels of an object
+// to their effective value, which includes the default annotations and labels of the provider.
+func patchMetadata(pathPrefix string, d *schema.ResourceData, providerMeta interface{}) PatchOperations {
 	ops := make([]PatchOperation, 0)
-	if d.HasChange(keyPrefix + "annotations") {
-		oldV, newV := d.GetChange(keyPrefix + "annotations")
+	if d.HasChange("effective_annotations") {
+		oldV, newV := d.GetChange("effective_annotations")
 		diffOps := diffStringMap(pathPrefix+"annotations", oldV.(map[string]interface{}), newV.(map[string]interface{}))
 		ops = append(ops, diffOps...)
 	}
-	if d.HasChange(keyPrefix + "labels") {
-		oldV, newV := d.GetChange(keyPrefix + "labels")
-		diffOps := diffStringMap(pathPrefix+"labels", oldV.(map[string]interface{}), newV.(map[string]interface{}))
+	if d.HasChange("effective_labels") {
+		oldV, newV := d.GetChange("effective_labels")
+		diffOps := diffStringMap(pathPrefix+"labels", oldV.(map[string]interface{}), withApplySetLabel(newV.(map[string]interface{}), providerMeta))
 		ops = append(ops, diffOps...)
 	}
 	return ops
 }
 
+// adoptMetadata returns the operations to add the effective annotations and labels of a resource
+// to an existing object it adopts, like the default service account of a namespace. The keys are added
+// one at a time, so that the annotations and labels already set on the object are kept.
+func adoptMetadata(pathPrefix string, live metav1.ObjectMeta, d *schema.ResourceData, providerMeta interface{}) PatchOperations {
+	ops := make([]PatchOperation, 0)
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	applyProviderMetadata(&metadata, providerMeta)
+	ops = append(ops, addStringMapKeys(pathPrefix+"annotations", live.Annotations, metadata.Annotations)...)
+	ops = append(ops, addStringMapKeys(pathPrefix+"labels", live.Labels, metadata.Labels)...)
+	return ops
+}
+
+// withApplySetLabel adds the label marking an object as a member of the provider's ApplySet to a copy of labels,
+// as the ApplySet label is not part of the effective labels and would be removed otherwise
+func withApplySetLabel(labels map[string]interface{}, providerMeta interface{}) map[string]interface{} {
+	pm, ok := providerMeta.(providerMetadata)
+	if !ok || pm.ApplySetID == "" {
+		return labels
+	}
+	l := make(map[string]interface{}, len(labels)+1)
+	for k, v := range labels {
+		l[k] = v
+	}
+	l[util.ApplySetPartOfLabel] = pm.ApplySetID
+	return l
+}
+
+// addStringMapKeys returns the operations to set the keys of newV on a map of an existing object,
+// leaving the other keys of the map untouched
+func addStringMapKeys(path string, live, newV map[string]string) PatchOperations {
+	ops := make([]PatchOperation, 0)
+	if len(newV) == 0 {
+		return ops
+	}
+	if len(live) == 0 {
+		ops = append(ops, &AddOperation{
+			Path:  path,
+			Value: newV,
+		})
+		return ops
+	}
+	for k, v := range newV {
+		if lv, ok := live[k]; ok && lv == v {
+			continue
+		}
+		ops = append(ops, &AddOperation{
+			Path:  path + "/" + escapeJsonPointer(k),
+			Value: v,
+		})
+	}
+	return ops
+}
+
 func expandStringMap(m map[string]interface{}) map[string]string {
 	result := make(map[string]string)
 	for k, v := range m {
@@ -134,17 +208,40 @@ func flattenMetadataFields(meta metav1.ObjectMeta) []interface{} {
 	return []interface{}{m}
 }
 
+// flattenMetadata flattens the metadata of the object managed by a resource, and sets the effective annotations
+// and labels of the object. The default annotations and labels of the provider are only kept in the metadata
+// when they are set on the resource.
 func flattenMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, providerMeta interface{}) []interface{} {
 	metadataAnnotations := d.Get("metadata.0.annotations").(map[string]interface{})
 	metadataLabels := d.Get("metadata.0.labels").(map[string]interface{})
+	pm := providerMeta.(providerMetadata)
+
+	// the keys set on the resource or by default at the provider level are kept even when they would be ignored otherwise
+	keepAnnotations := withDefaultKeys(metadataAnnotations, pm.DefaultAnnotations)
+	removeInternalKeys(meta.Annotations, keepAnnotations)
+	removeKeys(meta.Annotations, keepAnnotations, pm.IgnoreAnnotations)
+	d.Set("effective_annotations", meta.Annotations)
+	meta.Annotations = withoutDefaultKeys(meta.Annotations, metadataAnnotations, pm.DefaultAnnotations)
+
+	keepLabels := withDefaultKeys(metadataLabels, pm.DefaultLabels)
+	removeInternalKeys(meta.Labels, keepLabels)
+	removeKeys(meta.Labels, keepLabels, pm.IgnoreLabels)
+	d.Set("effective_labels", meta.Labels)
+	meta.Labels = withoutDefaultKeys(meta.Labels, metadataLabels, pm.DefaultLabels)
+
+	return flattenMetadataFields(meta)
+}
+
+// flattenTemplateMetadata flattens the metadata of an object template, like the job template of a cron job
+func flattenTemplateMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, providerMeta interface{}) []interface{} {
+	metadataAnnotations := d.Get("metadata.0.annotations").(map[string]interface{})
+	metadataLabels := d.Get("metadata.0.labels").(map[string]interface{})
 
-	ignoreAnnotations := providerMeta.(providerMetadata).IgnoreAnnotations
 	removeInternalKeys(meta.Annotations, metadataAnnotations)
-	removeKeys(meta.Annotations, metadataAnnotations, ignoreAnnotations)
+	removeKeys(meta.Annotations, metadataAnnotations, providerMeta.(providerMetadata).IgnoreAnnotations)
 
-	ignoreLabels := providerMeta.(providerMetadata).IgnoreLabels
 	removeInternalKeys(meta.Labels, metadataLabels)
-	removeKeys(meta.Labels, metadataLabels, ignoreLabels)
+	removeKeys(meta.Labels, metadataLabels, providerMeta.(providerMetadata).IgnoreLabels)
 
 	return flattenMetadataFields(meta)
 }
@@ -167,6 +264,38 @@ func removeKeys(m map[string]string, d map[string]interface{}, ignoreKubernetesM
 	}
 }
 
+// withDefaultKeys returns the keys set on a resource together with the keys set by default at the provider level
+func withDefaultKeys(d map[string]interface{}, defaults map[string]string) map[string]interface{} {
+	if len(defaults) == 0 {
+		return d
+	}
+	keys := make(map[string]interface{}, len(d)+len(defaults))
+	for k, v := range defaults {
+		keys[k] = v
+	}
+	for k, v := range d {
+		keys[k] = v
+	}
+	return keys
+}
+
+// withoutDefaultKeys returns a copy of the map without the keys set by default at the provider level,
+// so that they don't show as a difference with the configuration of the resource.
+// Their value is tracked in the effective annotations and labels instead.
+func withoutDefaultKeys(m map[string]string, d map[string]interface{}, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 || m == nil {
+		return m
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if _, ok := defaults[k]; ok && !isKeyInMap(k, d) {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
 func isKeyInMap(key string, d map[string]interface{}) bool {
 	_, ok := d[key]
 	return ok
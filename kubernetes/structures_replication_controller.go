@@ -28,7 +28,7 @@ func flattenReplicationControllerSpec(in corev1.ReplicationControllerSpec, d *sc
 		}
 		template := make(map[string]interface{})
 		template["spec"] = podSpec
-		template["metadata"] = flattenMetadata(in.Template.ObjectMeta, d, meta)
+		template["metadata"] = flattenTemplateMetadata(in.Template.ObjectMeta, d, meta)
 		att["template"] = []interface{}{template}
 	}
 
@@ -4,9 +4,11 @@
 package kubernetes
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -269,6 +271,41 @@ func TestFlattenMetadata(t *testing.T) {
 				"uid":              uid,
 			}},
 		},
+		"DefaultAnnotationsAndLabels": {
+			metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"foo.example.com":  "bar",
+					"team.example.com": "platform",
+				},
+				GenerateName: "",
+				Generation:   1,
+				Labels: map[string]string{
+					"foo":  "bar",
+					"team": "platform",
+					"env":  "staging",
+				},
+				Name:            "foo",
+				Namespace:       "",
+				ResourceVersion: "1",
+				UID:             types.UID(uid),
+			},
+			providerMetadata{
+				DefaultAnnotations: map[string]string{"team.example.com": "platform"},
+				DefaultLabels:      map[string]string{"team": "platform", "env": "production"},
+			},
+			[]interface{}{map[string]interface{}{
+				"annotations": map[string]string{
+					"foo.example.com": "bar",
+				},
+				"generation": int64(1),
+				"labels": map[string]string{
+					"foo": "bar",
+				},
+				"name":             "foo",
+				"resource_version": "1",
+				"uid":              uid,
+			}},
+		},
 	}
 	rawData := map[string]interface{}{
 		"metadata": []interface{}{map[string]interface{}{
@@ -280,9 +317,9 @@ func TestFlattenMetadata(t *testing.T) {
 			"uid":              "",
 		}},
 	}
-	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"metadata": namespacedMetadataSchema("fake", true)}, rawData)
 	for n, c := range cases {
 		t.Run(n, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"metadata": namespacedMetadataSchema("fake", true)}, rawData)
 			out := flattenMetadata(c.meta, d, c.providerMeta)
 			if !reflect.DeepEqual(out, c.expected) {
 				t.Fatalf("Error matching output and expected: %#v vs %#v", out, c.expected)
@@ -290,3 +327,142 @@ func TestFlattenMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyProviderMetadata(t *testing.T) {
+	cases := map[string]struct {
+		meta         metav1.ObjectMeta
+		providerMeta interface{}
+		expected     map[string]string
+	}{
+		"NoApplySet": {
+			meta:         metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+			providerMeta: providerMetadata{},
+			expected:     map[string]string{"foo": "bar"},
+		},
+		"ApplySet": {
+			meta:         metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+			providerMeta: providerMetadata{ApplySetID: "applyset-abc-v1"},
+			expected: map[string]string{
+				"foo":                            "bar",
+				"applyset.kubernetes.io/part-of": "applyset-abc-v1",
+			},
+		},
+		"DefaultLabels": {
+			meta:         metav1.ObjectMeta{Labels: map[string]string{"foo": "bar", "team": "data"}},
+			providerMeta: providerMetadata{DefaultLabels: map[string]string{"team": "platform", "env": "production"}},
+			expected: map[string]string{
+				"foo":  "bar",
+				"team": "data",
+				"env":  "production",
+			},
+		},
+		"ApplySetWithoutLabels": {
+			meta:         metav1.ObjectMeta{},
+			providerMeta: providerMetadata{ApplySetID: "applyset-abc-v1"},
+			expected: map[string]string{
+				"applyset.kubernetes.io/part-of": "applyset-abc-v1",
+			},
+		},
+	}
+	for n, c := range cases {
+		t.Run(n, func(t *testing.T) {
+			applyProviderMetadata(&c.meta, c.providerMeta)
+			if !reflect.DeepEqual(c.meta.Labels, c.expected) {
+				t.Fatalf("Error matching output and expected: %#v vs %#v", c.meta.Labels, c.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenMetadataEffective(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("fake", true),
+		},
+		UpdateContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil },
+	}
+	withEffectiveMetadata(r)
+	d := r.TestResourceData()
+	meta := metav1.ObjectMeta{
+		Labels: map[string]string{
+			"foo":  "bar",
+			"team": "platform",
+			"env":  "staging",
+		},
+	}
+	providerMeta := providerMetadata{
+		DefaultLabels: map[string]string{"team": "platform", "env": "production"},
+	}
+	flattenMetadata(meta, d, providerMeta)
+
+	expected := map[string]interface{}{
+		"foo":  "bar",
+		"team": "platform",
+		"env":  "staging",
+	}
+	if out := d.Get("effective_labels"); !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, expected)
+	}
+}
+
+func TestPatchMetadata(t *testing.T) {
+	// the effective labels are set in the configuration, to get the diff the provider plans for them
+	s := map[string]*schema.Schema{
+		"metadata":              namespacedMetadataSchema("fake", true),
+		"effective_annotations": {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"effective_labels":      {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"effective_labels": map[string]interface{}{"foo": "bar", "team": "platform"},
+	})
+
+	ops := patchMetadata("/metadata/", d, providerMetadata{ApplySetID: "applyset-abc-v1"})
+	expected := PatchOperations{
+		&AddOperation{
+			Path: "/metadata/labels",
+			Value: map[string]interface{}{
+				"foo":                            "bar",
+				"team":                           "platform",
+				"applyset.kubernetes.io/part-of": "applyset-abc-v1",
+			},
+		},
+	}
+	if !ops.Equal(expected) {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", ops, expected)
+	}
+}
+
+func TestAdoptMetadata(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"metadata": namespacedMetadataSchema("fake", true),
+	}
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name":   "default",
+			"labels": map[string]interface{}{"foo": "bar"},
+		}},
+	})
+	live := metav1.ObjectMeta{
+		Name:   "default",
+		Labels: map[string]string{"existing": "true", "team": "data"},
+	}
+	pm := providerMetadata{
+		DefaultAnnotations: map[string]string{"example.com/owner": "platform"},
+		DefaultLabels:      map[string]string{"team": "platform"},
+		ApplySetID:         "applyset-abc-v1",
+	}
+
+	ops := adoptMetadata("/metadata/", live, d, pm)
+	expected := PatchOperations{
+		&AddOperation{
+			Path:  "/metadata/annotations",
+			Value: map[string]string{"example.com/owner": "platform"},
+		},
+		&AddOperation{Path: "/metadata/labels/foo", Value: "bar"},
+		&AddOperation{Path: "/metadata/labels/team", Value: "platform"},
+		&AddOperation{Path: "/metadata/labels/applyset.kubernetes.io~1part-of", Value: "applyset-abc-v1"},
+	}
+	if !ops.Equal(expected) {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", ops, expected)
+	}
+}
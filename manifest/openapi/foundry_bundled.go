@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// bundledSpecs holds the OpenAPI v2 documents describing the built-in Kubernetes API types,
+// one per supported minor version. Each file is a gzip-compressed copy of api/openapi-spec/swagger.json
+// from the kubernetes/kubernetes repository, taken at the latest patch release of that minor version.
+//
+//go:embed specs/swagger-*.json.gz
+var bundledSpecs embed.FS
+
+const (
+	bundledSpecPrefix = "swagger-"
+	bundledSpecSuffix = ".json.gz"
+)
+
+// BundledSpecVersions returns the Kubernetes minor versions (e.g. "1.33") for which
+// an OpenAPI spec is bundled with the provider.
+func BundledSpecVersions() []string {
+	files, err := fs.Glob(bundledSpecs, "specs/"+bundledSpecPrefix+"*"+bundledSpecSuffix)
+	if err != nil {
+		return nil
+	}
+	versions := make([]string, 0, len(files))
+	for _, f := range files {
+		v := strings.TrimPrefix(f, "specs/"+bundledSpecPrefix)
+		versions = append(versions, strings.TrimSuffix(v, bundledSpecSuffix))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// HasBundledSpec reports whether an OpenAPI spec is bundled with the provider for the specified Kubernetes version.
+func HasBundledSpec(version string) bool {
+	_, err := fs.Stat(bundledSpecs, bundledSpecFile(version))
+	return err == nil
+}
+
+// bundledSpecFile returns the name of the embedded spec file for a Kubernetes version.
+// Patch versions are tolerated, as the API types don't change between them.
+func bundledSpecFile(version string) string {
+	v := strings.TrimPrefix(version, "v")
+	if p := strings.SplitN(v, ".", 3); len(p) == 3 {
+		v = p[0] + "." + p[1]
+	}
+	return "specs/" + bundledSpecPrefix + v + bundledSpecSuffix
+}
+
+// NewFoundryFromBundledSpec creates a new tftypes.Type foundry from the OpenAPI v2 spec
+// bundled with the provider for the specified Kubernetes minor version.
+// * version argument should be a Kubernetes minor version, like "1.33" or "v1.33"
+func NewFoundryFromBundledSpec(version string) (Foundry, error) {
+	f, err := bundledSpecs.Open(bundledSpecFile(version))
+	if err != nil {
+		return nil, fmt.Errorf("no OpenAPI spec bundled for Kubernetes version %q (available: %s)",
+			version, strings.Join(BundledSpecVersions(), ", "))
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress bundled OpenAPI spec for Kubernetes version %q: %s", version, err)
+	}
+	defer zr.Close()
+
+	var spec bytes.Buffer
+	if _, err := io.Copy(&spec, zr); err != nil {
+		return nil, fmt.Errorf("failed to read bundled OpenAPI spec for Kubernetes version %q: %s", version, err)
+	}
+
+	return NewFoundryFromSpecV2(spec.Bytes())
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBundledSpecVersions(t *testing.T) {
+	versions := BundledSpecVersions()
+	if len(versions) == 0 {
+		t.Fatal("no bundled OpenAPI specs found")
+	}
+	for _, v := range versions {
+		if _, err := NewFoundryFromBundledSpec(v); err != nil {
+			t.Errorf("failed to load bundled spec %q: %s", v, err)
+		}
+	}
+}
+
+func TestNewFoundryFromBundledSpecUnknownVersion(t *testing.T) {
+	_, err := NewFoundryFromBundledSpec("1.0")
+	if err == nil {
+		t.Fatal("expected error for a version without a bundled spec")
+	}
+}
+
+func TestBundledFoundry(t *testing.T) {
+	f, err := NewFoundryFromBundledSpec("v1.33.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples := map[string]struct {
+		gvk        schema.GroupVersionKind
+		namespaced bool
+	}{
+		"ConfigMap": {
+			gvk:        schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"},
+			namespaced: true,
+		},
+		"Deployment": {
+			gvk:        schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			namespaced: true,
+		},
+		"Namespace": {
+			gvk:        schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"},
+			namespaced: false,
+		},
+		"ClusterRole": {
+			gvk:        schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+			namespaced: false,
+		},
+	}
+
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			rt, _, err := f.GetTypeByGVK(s.gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !rt.Is(tftypes.Object{}) {
+				t.Fatalf("expected an object type, got %s", rt)
+			}
+			ns, err := f.(ScopeFinder).IsNamespaced(s.gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ns != s.namespaced {
+				t.Fatalf("expected namespaced to be %t, got %t", s.namespaced, ns)
+			}
+		})
+	}
+
+	_, err = f.(ScopeFinder).IsNamespaced(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Foo"})
+	if err == nil {
+		t.Fatal("expected error for a GVK that is not in the bundled spec")
+	}
+}
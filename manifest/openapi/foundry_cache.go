@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package openapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SpecCache stores OpenAPI documents retrieved from an API server on disk,
+// so that they can be reused by subsequent runs of the provider.
+//
+// Documents are stored under a directory specific to the API server address and
+// version. Each document is stored along with a validator (like an ETag) which
+// callers use to check that the cached copy is still current before using it.
+type SpecCache struct {
+	dir string
+}
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._+-]`)
+
+// NewSpecCache returns a cache of OpenAPI documents for the API server at 'host' running 'serverVersion'.
+// Documents cached for other versions of the same API server are discarded, as they can't be current anymore.
+func NewSpecCache(root, host, serverVersion string) (*SpecCache, error) {
+	if root == "" {
+		return nil, errors.New("no cache directory specified")
+	}
+	if serverVersion == "" {
+		return nil, errors.New("API server version is required to key the cache")
+	}
+	hs := sha256.Sum256([]byte(host))
+	hostDir := filepath.Join(root, hex.EncodeToString(hs[:8]))
+	version := unsafePathChars.ReplaceAllString(serverVersion, "_")
+
+	dir := filepath.Join(hostDir, version)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create OpenAPI cache directory: %s", err)
+	}
+
+	// the server has changed version - drop documents cached for previous versions
+	entries, err := os.ReadDir(hostDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI cache directory: %s", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != version {
+			os.RemoveAll(filepath.Join(hostDir, e.Name()))
+		}
+	}
+
+	return &SpecCache{dir: dir}, nil
+}
+
+// Get returns the cached document for 'key', along with the validator it was stored with.
+func (c *SpecCache) Get(key string) (spec []byte, validator string, ok bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, "", false
+	}
+	// the validator is stored on the first line, followed by the document
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 {
+		return nil, "", false
+	}
+	return data[i+1:], string(data[:i]), true
+}
+
+// Put stores a document for 'key' along with its validator.
+// The cache entry is replaced atomically, so that concurrent readers never observe partial documents.
+func (c *SpecCache) Put(key, validator string, spec []byte) error {
+	if bytes.ContainsAny([]byte(validator), "\r\n") {
+		return fmt.Errorf("invalid validator for cached document %q", key)
+	}
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(validator + "\n")
+	if err == nil {
+		_, err = f.Write(spec)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path(key))
+}
+
+func (c *SpecCache) path(key string) string {
+	ks := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(ks[:])+".json")
+}
+
+// cachedFoundry is a Foundry which stores the types it derives from an OpenAPI spec in a SpecCache,
+// so that subsequent runs of the provider don't have to parse the spec again.
+type cachedFoundry struct {
+	cache     *SpecCache
+	prefix    string
+	validator string
+	load      func() (Foundry, error)
+
+	once    sync.Once
+	foundry Foundry
+	err     error
+}
+
+// cachedType is the representation of a type and its hints in a SpecCache
+type cachedType struct {
+	Type  json.RawMessage   `json:"type"`
+	Hints map[string]string `json:"hints,omitempty"`
+}
+
+// NewCachedFoundry returns a Foundry which looks up types in the cache first, under keys starting with 'prefix'.
+// Types are only reused when they were derived from the spec identified by 'validator', such as its ETag.
+// The Foundry returned by 'load' is only created when a type is missing from the cache.
+func NewCachedFoundry(cache *SpecCache, prefix, validator string, load func() (Foundry, error)) Foundry {
+	return &cachedFoundry{
+		cache:     cache,
+		prefix:    prefix,
+		validator: validator,
+		load:      load,
+	}
+}
+
+func (f *cachedFoundry) GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[string]string, error) {
+	key := f.prefix + gvk.String()
+	if data, validator, ok := f.cache.Get(key); ok && validator == f.validator {
+		var ct cachedType
+		if err := json.Unmarshal(data, &ct); err == nil {
+			if t, err := tftypes.ParseJSONType(ct.Type); err == nil {
+				return t, ct.Hints, nil
+			}
+		}
+	}
+
+	f.once.Do(func() {
+		f.foundry, f.err = f.load()
+	})
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	t, hints, err := f.foundry.GetTypeByGVK(gvk)
+	if err != nil {
+		return t, hints, err
+	}
+	// failing to cache a type only costs the time to derive it again on the next run
+	if tj, err := t.MarshalJSON(); err == nil {
+		if data, err := json.Marshal(cachedType{Type: tj, Hints: hints}); err == nil {
+			f.cache.Put(key, f.validator, data)
+		}
+	}
+	return t, hints, nil
+}
@@ -0,0 +1,131 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestSpecCache(t *testing.T) {
+	root := t.TempDir()
+	host := "https://127.0.0.1:6443"
+
+	c, err := NewSpecCache(root, host, "v1.32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := c.Get("openapi/v2"); ok {
+		t.Fatal("expected empty cache")
+	}
+	spec := []byte("{\n  \"swagger\": \"2.0\"\n}")
+	if err := c.Put("openapi/v2", `"ABCDEF"`, spec); err != nil {
+		t.Fatal(err)
+	}
+
+	// a new process against the same server sees the cached document
+	c, err = NewSpecCache(root, host, "v1.32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, validator, ok := c.Get("openapi/v2")
+	if !ok {
+		t.Fatal("expected cached document")
+	}
+	if validator != `"ABCDEF"` {
+		t.Fatalf("unexpected validator: %s", validator)
+	}
+	if string(got) != string(spec) {
+		t.Fatalf("unexpected document: %s", got)
+	}
+
+	// documents are not shared between API servers
+	other, err := NewSpecCache(root, "https://10.0.0.1:6443", "v1.32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := other.Get("openapi/v2"); ok {
+		t.Fatal("expected document to not be shared with other servers")
+	}
+
+	// upgrading the server invalidates the cache
+	c, err = NewSpecCache(root, host, "v1.33.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := c.Get("openapi/v2"); ok {
+		t.Fatal("expected cache to be invalidated by a server version change")
+	}
+	c, err = NewSpecCache(root, host, "v1.32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := c.Get("openapi/v2"); ok {
+		t.Fatal("expected documents of previous server versions to be removed")
+	}
+
+	if err := c.Put("openapi/v2", "bad\nvalidator", spec); err == nil {
+		t.Fatal("expected error for a validator spanning multiple lines")
+	}
+}
+
+type countingFoundry struct {
+	calls int
+}
+
+func (f *countingFoundry) GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[string]string, error) {
+	f.calls++
+	t := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"data":  tftypes.Map{ElementType: tftypes.String},
+			"ports": tftypes.List{ElementType: tftypes.Number},
+		},
+	}
+	return t, map[string]string{"AttributeName(\"ports\")": "io.k8s.apimachinery.pkg.util.intstr.IntOrString"}, nil
+}
+
+func TestCachedFoundry(t *testing.T) {
+	c, err := NewSpecCache(t.TempDir(), "https://127.0.0.1:6443", "v1.32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	f := &countingFoundry{}
+	loads := 0
+	load := func() (Foundry, error) {
+		loads++
+		return f, nil
+	}
+
+	expected, expectedHints, _ := f.GetTypeByGVK(gvk)
+	f.calls = 0
+	for i := 0; i < 2; i++ {
+		// each new foundry stands for a new run of the provider
+		cf := NewCachedFoundry(c, "openapi/v2/types/", `"ABCDEF"`, load)
+		typ, hints, err := cf.GetTypeByGVK(gvk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !typ.Equal(expected) {
+			t.Fatalf("expected type %s, got %s", expected, typ)
+		}
+		if hints["AttributeName(\"ports\")"] != expectedHints["AttributeName(\"ports\")"] {
+			t.Fatalf("expected hints %v, got %v", expectedHints, hints)
+		}
+	}
+	if loads != 1 || f.calls != 1 {
+		t.Fatalf("expected the type to be derived once, loaded %d times and derived %d times", loads, f.calls)
+	}
+
+	// types derived from another version of the spec are not reused
+	cf := NewCachedFoundry(c, "openapi/v2/types/", `"012345"`, load)
+	if _, _, err := cf.GetTypeByGVK(gvk); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 || f.calls != 2 {
+		t.Fatalf("expected the type to be derived again, loaded %d times and derived %d times", loads, f.calls)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/getkin/kin-openapi/openapi2"
@@ -55,10 +56,18 @@ type Foundry interface {
 	GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[string]string, error)
 }
 
+// ScopeFinder is implemented by foundries which can tell whether a resource is namespaced
+// from the API paths described in their spec, without calling the discovery API.
+type ScopeFinder interface {
+	IsNamespaced(gvk schema.GroupVersionKind) (bool, error)
+}
+
 type foapiv2 struct {
 	swagger        *openapi2.T
 	typeCache      sync.Map
 	gkvIndex       sync.Map
+	scopeIndex     map[schema.GroupVersionKind]bool
+	scopeOnce      sync.Once
 	recursionDepth uint64 // a last resort circuit-breaker for run-away recursion - hitting this will make for a bad day
 	gate           sync.Mutex
 }
@@ -131,3 +140,41 @@ func (f *foapiv2) buildGvkIndex() error {
 	}
 	return nil
 }
+
+// IsNamespaced reports whether the resource designated by the GVK is namespaced,
+// based on the API paths under which the spec exposes operations for it.
+func (f *foapiv2) IsNamespaced(gvk schema.GroupVersionKind) (bool, error) {
+	f.scopeOnce.Do(f.buildScopeIndex)
+	ns, ok := f.scopeIndex[gvk]
+	if !ok {
+		return false, fmt.Errorf("%v resource not found in OpenAPI paths", gvk)
+	}
+	return ns, nil
+}
+
+// buildScopeIndex associates each GVK that has API operations in the spec to its scope.
+// A resource is namespaced if any of its operations is served under a "{namespace}" path.
+func (f *foapiv2) buildScopeIndex() {
+	f.scopeIndex = make(map[schema.GroupVersionKind]bool)
+	for p, pi := range f.swagger.Paths {
+		if pi == nil {
+			continue
+		}
+		namespaced := strings.Contains(p, "/namespaces/{namespace}/")
+		for _, op := range pi.Operations() {
+			ex, ok := op.Extensions["x-kubernetes-group-version-kind"]
+			if !ok {
+				continue
+			}
+			raw, ok := ex.(json.RawMessage)
+			if !ok {
+				continue
+			}
+			var gvk schema.GroupVersionKind
+			if err := json.Unmarshal(raw, &gvk); err != nil {
+				continue
+			}
+			f.scopeIndex[gvk] = f.scopeIndex[gvk] || namespaced
+		}
+	}
+}
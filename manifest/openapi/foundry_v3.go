@@ -4,6 +4,7 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -64,3 +65,113 @@ func (f *foapiv3) GetTypeByGVK(_ schema.GroupVersionKind) (tftypes.Type, map[str
 	tftype, err := getTypeFromSchema(sch, 50, &(f.typeCache), f.doc.Components.Schemas, ap, hints)
 	return tftype, hints, err
 }
+
+// NewFoundryFromGroupVersionSpecsV3 creates a new tftypes.Type foundry from the OpenAPI v3 documents
+// the Kubernetes API server publishes for each GroupVersion under /openapi/v3.
+// * fetch argument should return the JSON document for a GroupVersion. It is called lazily,
+// the first time a type from that GroupVersion is requested.
+func NewFoundryFromGroupVersionSpecsV3(fetch func(gv schema.GroupVersion) ([]byte, error)) Foundry {
+	return &foapiv3gv{
+		fetch:          fetch,
+		docs:           make(map[schema.GroupVersion]*gvDocV3),
+		recursionDepth: 50, // arbitrarily large number - a type this deep will likely kill Terraform anyway
+	}
+}
+
+type gvDocV3 struct {
+	doc      *openapi3.T
+	gvkIndex map[schema.GroupVersionKind]string // reverse lookup index from GVK to OpenAPI schema IDs
+}
+
+type foapiv3gv struct {
+	fetch          func(gv schema.GroupVersion) ([]byte, error)
+	docs           map[schema.GroupVersion]*gvDocV3
+	typeCache      sync.Map
+	recursionDepth uint64
+	gate           sync.Mutex
+}
+
+// GetTypeByGVK looks up a type by its GVK in the components of the OpenAPI v3 document
+// for the GVK's GroupVersion and returns its (nearest) tftypes.Type equivalent
+func (f *foapiv3gv) GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[string]string, error) {
+	f.gate.Lock()
+	defer f.gate.Unlock()
+
+	var hints map[string]string = make(map[string]string)
+	ap := tftypes.AttributePath{}
+
+	d, err := f.getDocument(gvk.GroupVersion())
+	if err != nil {
+		return nil, hints, err
+	}
+
+	// ObjectMeta isn't tagged with "x-kubernetes-group-version-kind", but every document
+	// describing resources includes it in its components. The core/v1 one is always present.
+	id, ok := d.gvkIndex[gvk]
+	if gvk == ObjectMetaGVK {
+		id, ok = "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta", true
+	}
+	if !ok {
+		return nil, hints, fmt.Errorf("%v resource not found in OpenAPI v3 document", gvk)
+	}
+
+	sref, ok := d.doc.Components.Schemas[id]
+	if !ok || sref == nil {
+		return nil, hints, fmt.Errorf("invalid type identifier %q", id)
+	}
+	sch, err := resolveSchemaRef(sref, d.doc.Components.Schemas)
+	if err != nil {
+		return nil, hints, fmt.Errorf("failed to resolve schema: %s", err)
+	}
+
+	t, err := getTypeFromSchema(sch, f.recursionDepth, &(f.typeCache), d.doc.Components.Schemas, ap, hints)
+	return t, hints, err
+}
+
+// getDocument returns the parsed OpenAPI v3 document for a GroupVersion, fetching it if needed
+func (f *foapiv3gv) getDocument(gv schema.GroupVersion) (*gvDocV3, error) {
+	if d, ok := f.docs[gv]; ok {
+		return d, nil
+	}
+
+	spec, err := f.fetch(gv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI v3 document for %q: %w", gv.String(), err)
+	}
+	// References are deliberately left unresolved, as they would be by the loader, because
+	// recursive types would turn into cycles. They are resolved by ID when building types.
+	doc := &openapi3.T{}
+	err = doc.UnmarshalJSON(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI v3 document for %q: %s", gv.String(), err)
+	}
+
+	d := &gvDocV3{
+		doc:      doc,
+		gvkIndex: make(map[schema.GroupVersionKind]string),
+	}
+	for id, sref := range doc.Components.Schemas {
+		if sref == nil || sref.Value == nil {
+			continue
+		}
+		ex, ok := sref.Value.Extensions["x-kubernetes-group-version-kind"]
+		if !ok {
+			continue
+		}
+		raw, ok := ex.(json.RawMessage)
+		if !ok {
+			continue
+		}
+		gvks := []schema.GroupVersionKind{}
+		err = json.Unmarshal(raw, &gvks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshall GVK from OpenAPI schema extention: %v", err)
+		}
+		for i := range gvks {
+			d.gvkIndex[gvks[i]] = id
+		}
+	}
+	f.docs[gv] = d
+
+	return d, nil
+}
@@ -6,6 +6,9 @@ package openapi
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestNewFoundryFromSpecV3(t *testing.T) {
@@ -66,3 +69,105 @@ func TestNewFoundryFromSpecV3(t *testing.T) {
 		t.Fail()
 	}
 }
+
+const sampleGroupVersionSpecV3 = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "v1.33.0"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "io.k8s.api.example.v1.Widget": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}], "default": {}},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.example.v1.WidgetSpec"}], "default": {}}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "example.k8s.io", "kind": "Widget", "version": "v1"}]
+      },
+      "io.k8s.api.example.v1.WidgetSpec": {
+        "type": "object",
+        "properties": {
+          "port": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.util.intstr.IntOrString"}]},
+          "limits": {"type": "object", "additionalProperties": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.api.resource.Quantity"}]}},
+          "replicas": {"type": "integer", "format": "int32", "nullable": true}
+        }
+      },
+      "io.k8s.apimachinery.pkg.api.resource.Quantity": {
+        "oneOf": [{"type": "string"}, {"type": "number"}]
+      },
+      "io.k8s.apimachinery.pkg.util.intstr.IntOrString": {
+        "format": "int-or-string",
+        "oneOf": [{"type": "integer"}, {"type": "string"}]
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "namespace": {"type": "string"},
+          "labels": {"type": "object", "additionalProperties": {"type": "string", "default": ""}}
+        }
+      }
+    }
+  }
+}`
+
+func TestFoundryFromGroupVersionSpecsV3(t *testing.T) {
+	fetched := map[schema.GroupVersion]int{}
+	f := NewFoundryFromGroupVersionSpecsV3(func(gv schema.GroupVersion) ([]byte, error) {
+		fetched[gv]++
+		return []byte(sampleGroupVersionSpecV3), nil
+	})
+
+	gvk := schema.GroupVersionKind{Group: "example.k8s.io", Version: "v1", Kind: "Widget"}
+	metaType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":      tftypes.String,
+		"namespace": tftypes.String,
+		"labels":    tftypes.Map{ElementType: tftypes.String},
+	}}
+	want := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"apiVersion": tftypes.String,
+		"kind":       tftypes.String,
+		"metadata":   metaType,
+		"spec": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"port":     tftypes.String,
+			"limits":   tftypes.Map{ElementType: tftypes.String},
+			"replicas": tftypes.Number,
+		}},
+	}}
+
+	rt, hints, err := f.GetTypeByGVK(gvk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rt.Equal(want) {
+		t.Fatalf("unexpected type:\nwant: %s\ngot:  %s", want, rt)
+	}
+	portPath := tftypes.NewAttributePath().WithAttributeName("spec").WithAttributeName("port")
+	if hints[portPath.String()] != "io.k8s.apimachinery.pkg.util.intstr.IntOrString" {
+		t.Fatalf("missing int-or-string hint, got: %v", hints)
+	}
+
+	// documents are only fetched once per GroupVersion
+	_, _, err = f.GetTypeByGVK(gvk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched[gvk.GroupVersion()] != 1 {
+		t.Fatalf("expected document to be fetched once, got %d", fetched[gvk.GroupVersion()])
+	}
+
+	mt, _, err := f.GetTypeByGVK(ObjectMetaGVK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mt.Equal(metaType) {
+		t.Fatalf("unexpected ObjectMeta type:\nwant: %s\ngot:  %s", metaType, mt)
+	}
+
+	_, _, err = f.GetTypeByGVK(schema.GroupVersionKind{Group: "example.k8s.io", Version: "v1", Kind: "Gadget"})
+	if err == nil {
+		t.Fatal("expected error for a kind missing from the document")
+	}
+}
@@ -17,8 +17,10 @@ import (
 )
 
 func resolveSchemaRef(ref *openapi3.SchemaRef, defs map[string]*openapi3.SchemaRef) (*openapi3.Schema, error) {
-	if ref.Value != nil {
-		return ref.Value, nil
+	// References are looked up by ID even when already resolved by the loader (as in OpenAPI v3 documents),
+	// so that the exceptional types below are handled consistently.
+	if ref.Value != nil && ref.Ref == "" {
+		return unwrapAllOf(ref.Value, defs)
 	}
 
 	rp := strings.Split(ref.Ref, "/")
@@ -50,6 +52,16 @@ func resolveSchemaRef(ref *openapi3.SchemaRef, defs map[string]*openapi3.SchemaR
 	return resolveSchemaRef(nref, defs)
 }
 
+// unwrapAllOf resolves schemas which only wrap a single reference in "allOf".
+// OpenAPI v3 documents published by Kubernetes use this construct to attach
+// defaults and descriptions to properties of a referenced type.
+func unwrapAllOf(sch *openapi3.Schema, defs map[string]*openapi3.SchemaRef) (*openapi3.Schema, error) {
+	if sch.Type == "" && len(sch.AllOf) == 1 && sch.Properties == nil && sch.AdditionalProperties == nil && sch.Items == nil {
+		return resolveSchemaRef(sch.AllOf[0], defs)
+	}
+	return sch, nil
+}
+
 func getTypeFromSchema(elem *openapi3.Schema, stackdepth uint64, typeCache *sync.Map, defs map[string]*openapi3.SchemaRef, ap tftypes.AttributePath, th map[string]string) (tftypes.Type, error) {
 	if stackdepth == 0 {
 		// this is a hack to overcome the inability to express recursion in tftypes
@@ -102,6 +114,15 @@ func getTypeFromSchema(elem *openapi3.Schema, stackdepth uint64, typeCache *sync
 		return tftypes.Number, nil
 
 	case "":
+		if elem.Format == "int-or-string" {
+			// OpenAPI v3 describes IntOrString as "oneOf" integer or string, without a type
+			th[ap.String()] = "io.k8s.apimachinery.pkg.util.intstr.IntOrString"
+			return tftypes.String, nil
+		}
+		if isStringOrNumber(elem) {
+			// OpenAPI v3 describes Quantity as "oneOf" string or number, without a type
+			return tftypes.String, nil
+		}
 		if xv, ok := elem.Extensions["x-kubernetes-int-or-string"]; ok {
 			xb, err := xv.(json.RawMessage).MarshalJSON()
 			if err != nil {
@@ -206,6 +227,27 @@ func getTypeFromSchema(elem *openapi3.Schema, stackdepth uint64, typeCache *sync
 	return nil, fmt.Errorf("unknown type: %s", elem.Type)
 }
 
+// isStringOrNumber checks if a schema only allows a choice between a string and numeric values
+func isStringOrNumber(elem *openapi3.Schema) bool {
+	if len(elem.OneOf) == 0 {
+		return false
+	}
+	hasString := false
+	for _, o := range elem.OneOf {
+		if o == nil || o.Value == nil {
+			return false
+		}
+		switch o.Value.Type {
+		case "string":
+			hasString = true
+		case "number", "integer":
+		default:
+			return false
+		}
+	}
+	return hasString
+}
+
 func isTypeFullyKnown(t tftypes.Type) bool {
 	if t.Is(tftypes.DynamicPseudoType) {
 		return false
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package payload
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
+)
+
+// FromYAMLValue converts a decoded YAML manifest into a tftypes.Value shaped like
+// the same manifest written in HCL: only the attributes present in the YAML are included,
+// and objects and lists are represented as tftypes.Object and tftypes.Tuple values.
+//
+// Unlike 'yamldecode', scalars are typed using the resource schema. Numbers are kept as
+// written in the YAML for string attributes (like IntOrString or Quantity values), the way kubectl would.
+// Arguments:
+//   - in : the decoded YAML value, with numbers decoded as json.Number
+//   - st : the type of the value in the resource schema. Pass in tftypes.DynamicPseudoType when not known.
+//   - th : type hints, as returned with the resource schema. Pass in empty map when not using hints.
+//   - at : attribute path which recursively tracks the conversion.
+func FromYAMLValue(in interface{}, st tftypes.Type, th map[string]string, at *tftypes.AttributePath) (tftypes.Value, error) {
+	if st == nil {
+		st = tftypes.DynamicPseudoType
+	}
+	switch t := in.(type) {
+	case nil:
+		if st.Is(tftypes.DynamicPseudoType) {
+			return tftypes.NewValue(tftypes.String, nil), nil
+		}
+		return tftypes.NewValue(st, nil), nil
+	case string:
+		return tftypes.NewValue(tftypes.String, t), nil
+	case bool:
+		return tftypes.NewValue(tftypes.Bool, t), nil
+	case json.Number:
+		ht := th[morph.ValueToTypePath(at).String()]
+		if st.Is(tftypes.String) || ht == "io.k8s.apimachinery.pkg.util.intstr.IntOrString" {
+			return tftypes.NewValue(tftypes.String, t.String()), nil
+		}
+		f, _, err := big.ParseFloat(t.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return tftypes.Value{}, at.NewErrorf("[%s] cannot parse number %q: %s", at.String(), t.String(), err)
+		}
+		return tftypes.NewValue(tftypes.Number, f), nil
+	case []interface{}:
+		vals := make([]tftypes.Value, len(t))
+		types := make([]tftypes.Type, len(t))
+		for i, e := range t {
+			eap := at.WithElementKeyInt(i)
+			var et tftypes.Type
+			switch {
+			case st.Is(tftypes.List{}):
+				et = st.(tftypes.List).ElementType
+			case st.Is(tftypes.Set{}):
+				et = st.(tftypes.Set).ElementType
+			case st.Is(tftypes.Tuple{}) && i < len(st.(tftypes.Tuple).ElementTypes):
+				et = st.(tftypes.Tuple).ElementTypes[i]
+			}
+			v, err := FromYAMLValue(e, et, th, eap)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			vals[i] = v
+			types[i] = v.Type()
+		}
+		return tftypes.NewValue(tftypes.Tuple{ElementTypes: types}, vals), nil
+	case map[string]interface{}:
+		vals := make(map[string]tftypes.Value, len(t))
+		types := make(map[string]tftypes.Type, len(t))
+		for k, e := range t {
+			if e == nil {
+				// like in the API payload, null attributes are left out
+				continue
+			}
+			eap := at.WithAttributeName(k)
+			var et tftypes.Type
+			switch {
+			case st.Is(tftypes.Object{}):
+				et = st.(tftypes.Object).AttributeTypes[k]
+			case st.Is(tftypes.Map{}):
+				eap = at.WithElementKeyString(k)
+				et = st.(tftypes.Map).ElementType
+			}
+			v, err := FromYAMLValue(e, et, th, eap)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			vals[k] = v
+			types[k] = v.Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, vals), nil
+	}
+	return tftypes.Value{}, at.NewErrorf(`[%s] cannot convert YAML value of unknown type "%s"`, at.String(), reflect.TypeOf(in).String())
+}
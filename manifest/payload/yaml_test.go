@@ -0,0 +1,101 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package payload
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFromYAMLValue(t *testing.T) {
+	portType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"port":       tftypes.Number,
+		"targetPort": tftypes.String,
+	}}
+	samples := map[string]struct {
+		In  interface{}
+		St  tftypes.Type
+		Th  map[string]string
+		Out tftypes.Value
+	}{
+		"string": {
+			In:  "foobar",
+			Out: tftypes.NewValue(tftypes.String, "foobar"),
+		},
+		"bool": {
+			In:  true,
+			Out: tftypes.NewValue(tftypes.Bool, true),
+		},
+		"number": {
+			In:  json.Number("42"),
+			Out: tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(42)),
+		},
+		"number-as-string": {
+			In:  json.Number("42"),
+			St:  tftypes.String,
+			Out: tftypes.NewValue(tftypes.String, "42"),
+		},
+		"int-or-string": {
+			In: map[string]interface{}{
+				"port":       json.Number("80"),
+				"targetPort": json.Number("8080"),
+			},
+			St: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"port":       tftypes.Number,
+				"targetPort": tftypes.DynamicPseudoType,
+			}},
+			Th: map[string]string{
+				tftypes.NewAttributePath().WithAttributeName("targetPort").String(): "io.k8s.apimachinery.pkg.util.intstr.IntOrString",
+			},
+			Out: tftypes.NewValue(portType, map[string]tftypes.Value{
+				"port":       tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(80)),
+				"targetPort": tftypes.NewValue(tftypes.String, "8080"),
+			}),
+		},
+		"list": {
+			In: []interface{}{
+				map[string]interface{}{
+					"port":       json.Number("80"),
+					"targetPort": json.Number("8080"),
+				},
+			},
+			St: tftypes.List{ElementType: portType},
+			Out: tftypes.NewValue(tftypes.Tuple{ElementTypes: []tftypes.Type{portType}}, []tftypes.Value{
+				tftypes.NewValue(portType, map[string]tftypes.Value{
+					"port":       tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(80)),
+					"targetPort": tftypes.NewValue(tftypes.String, "8080"),
+				}),
+			}),
+		},
+		"map": {
+			In: map[string]interface{}{
+				"cpu":    json.Number("2"),
+				"memory": "1Gi",
+				"unset":  nil,
+			},
+			St: tftypes.Map{ElementType: tftypes.String},
+			Out: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"cpu":    tftypes.String,
+				"memory": tftypes.String,
+			}}, map[string]tftypes.Value{
+				"cpu":    tftypes.NewValue(tftypes.String, "2"),
+				"memory": tftypes.NewValue(tftypes.String, "1Gi"),
+			}),
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			out, err := FromYAMLValue(s.In, s.St, s.Th, tftypes.NewAttributePath())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !out.Equal(s.Out) {
+				t.Fatalf("expected %s, got %s", s.Out, out)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ package provider
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -36,6 +35,16 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		return resp, nil
 	}
 
+	if req.TypeName == manifestsResourceType {
+		return s.applyManifests(ctx, req)
+	}
+	if req.TypeName == applySetResourceType {
+		return s.applyApplySet(ctx, req)
+	}
+	if req.TypeName == manifestPatchResourceType {
+		return s.applyManifestPatch(ctx, req)
+	}
+
 	rt, err := GetResourceType(req.TypeName)
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -178,11 +187,45 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			return resp, nil
 		}
 
-		tsch, th, err := s.TFTypeFromOpenAPI(ctx, gvk, false)
+		subresource, err := getSubresource(plannedStateVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid subresource",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("subresource"),
+			})
+			return resp, nil
+		}
+		var subresources []string
+		if subresource != "" {
+			subresources = append(subresources, subresource)
+		}
+
+		tsch, th, err := s.subresourceType(ctx, gvk, subresource)
 		if err != nil {
 			return resp, fmt.Errorf("failed to determine resource type ID: %s", err)
 		}
 
+		plannedMan, err := manifestValue(plannedStateVal, tsch, th)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to decode manifest",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		confMan, err := manifestValue(confVals, tsch, th)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to decode manifest",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+
 		// "Computed" attributes would have been replaced with Unknown values during
 		// planning in order to allow the response from apply to return potentially
 		// different values to the ones the user configured.
@@ -197,7 +240,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			if v.IsKnown() {
 				return v, nil
 			}
-			ppMan, restPath, err := tftypes.WalkAttributePath(plannedStateVal["manifest"], ap)
+			ppMan, restPath, err := tftypes.WalkAttributePath(plannedMan, ap)
 			if err != nil {
 				if len(restPath.Steps()) > 0 {
 					// attribute not in manifest
@@ -251,7 +294,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 				}
 				// check if attribute path is present in user-supplied manifest
 				// (this means the value is intentional, not structural)
-				_, restPath, err := tftypes.WalkAttributePath(confVals["manifest"], ap)
+				_, restPath, err := tftypes.WalkAttributePath(confMan, ap)
 				if (err == nil && len(restPath.Steps()) == 0) || !isEmpty {
 					// attribute is not empty and/or was set by the user -> retain
 					return tftypes.NewValue(v.Type(), atts), nil
@@ -286,6 +329,9 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 
 		// remove null attributes - the API doesn't appreciate requests that include them
 		rqObj := mapRemoveNulls(pu.(map[string]interface{}))
+		if subresource == "" {
+			s.setProviderMetadata(rqObj)
+		}
 
 		uo := unstructured.Unstructured{}
 		uo.SetUnstructuredContent(rqObj)
@@ -315,8 +361,23 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			rs = c.Resource(gvr)
 		}
 
-		// Check the resource does not exist if this is a create operation
-		if applyPriorState.IsNull() {
+		if subresource != "" {
+			// subresources can only be applied to a resource that already exists
+			_, err := rs.Get(ctx, rname, metav1.GetOptions{})
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics,
+					&tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  fmt.Sprintf("Failed to get resource %q to apply its %s subresource", rnn, subresource),
+						Detail:   err.Error(),
+					})
+				return resp, nil
+			}
+			if subresource == subresourceScale {
+				uo.SetGroupVersionKind(scaleGVK)
+			}
+		} else if applyPriorState.IsNull() {
+			// Check the resource does not exist if this is a create operation
 			_, err := rs.Get(ctx, rname, metav1.GetOptions{})
 			if err == nil {
 				resp.Diagnostics = append(resp.Diagnostics,
@@ -337,17 +398,6 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			}
 		}
 
-		jsonManifest, err := uo.MarshalJSON()
-		if err != nil {
-			resp.Diagnostics = append(resp.Diagnostics,
-				&tfprotov5.Diagnostic{
-					Severity: tfprotov5.DiagnosticSeverityError,
-					Detail:   err.Error(),
-					Summary:  fmt.Sprintf("Failed to marshall resource '%s' to JSON", rnn),
-				})
-			return resp, nil
-		}
-
 		// get fieldManager config
 		fieldManagerName, forceConflicts, err := s.getFieldManagerConfig(plannedStateVal)
 		if err != nil {
@@ -371,38 +421,45 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		ctxDeadline, cancel := context.WithDeadline(ctx, deadline)
 		defer cancel()
 
-		// Call the Kubernetes API to create the new resource
-		s.logger.Trace("[ApplyResourceChange][API Payload]: %s", jsonManifest)
-		result, err := rs.Patch(ctxDeadline, rname, types.ApplyPatchType, jsonManifest,
-			metav1.PatchOptions{
-				FieldManager: fieldManagerName,
-				Force:        &forceConflicts,
-			},
-		)
-		if err != nil {
-			s.logger.Error("[ApplyResourceChange][Apply]", "API error", dump(err), "API response", dump(result))
-			if apierrors.IsConflict(err) {
-				resp.Diagnostics = append(resp.Diagnostics,
-					&tfprotov5.Diagnostic{
-						Severity: tfprotov5.DiagnosticSeverityError,
-						Summary:  fmt.Sprintf(`There was a field manager conflict when trying to apply the manifest for %q`, rnn),
-						Detail: fmt.Sprintf(
-							"The API returned the following conflict: %q\n\n"+
-								"You can override this conflict by setting \"force_conflicts\" to true in the \"field_manager\" block.",
-							err.Error(),
-						),
-					},
-				)
-			} else if status := apierrors.APIStatus(nil); errors.As(err, &status) {
-				resp.Diagnostics = append(resp.Diagnostics, APIStatusErrorToDiagnostics(status.Status())...)
-			} else {
-				resp.Diagnostics = append(resp.Diagnostics,
-					&tfprotov5.Diagnostic{
+		// adopt the fields of imported resources previously managed with client-side apply
+		isImported, d := isImportedFlagFromPrivate(req.PlannedPrivate)
+		resp.Diagnostics = append(resp.Diagnostics, d...)
+		if isImported && !applyPriorState.IsNull() {
+			migrateFrom, err := getMigrateFromConfig(plannedStateVal)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "Could not extract field_manager config",
+					Detail:    err.Error(),
+					Attribute: tftypes.NewAttributePath().WithAttributeName("field_manager").WithElementKeyInt(0).WithAttributeName("migrate_from"),
+				})
+				return resp, nil
+			}
+			if len(migrateFrom) > 0 {
+				migrated, err := migrateManagedFields(ctxDeadline, rs, rname, migrateFrom, fieldManagerName, subresource, uo.Object)
+				if err != nil {
+					resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  fmt.Sprintf("Failed to migrate the field managers of %q", rnn),
 						Detail:   err.Error(),
-						Summary:  fmt.Sprintf(`PATCH for resource "%s" failed to apply`, rnn),
 					})
+					return resp, nil
+				}
+				s.logger.Debug("[ApplyResourceChange][MigrateManagedFields]", "resource", rnn, "from", migrateFrom, "to", fieldManagerName, "migrated", migrated)
+			}
+		}
+
+		// Call the Kubernetes API to create the new resource
+		s.logger.Trace("[ApplyResourceChange][API Payload]", "object", dump(uo.Object))
+		result, err := serverSideApply(ctxDeadline, rs, &uo, fieldManagerName, forceConflicts, subresources...)
+		if err != nil {
+			s.logger.Error("[ApplyResourceChange][Apply]", "API error", dump(err), "API response", dump(result))
+			// the fields of YAML manifests have no attribute paths of their own
+			conflictObj := rqObj
+			if manifestAttribute(plannedStateVal) == "manifest_yaml" {
+				conflictObj = nil
 			}
+			resp.Diagnostics = append(resp.Diagnostics, applyErrorToDiagnostics(err, rnn, conflictObj, tftypes.NewAttributePath().WithAttributeName(manifestAttribute(plannedStateVal)))...)
 			return resp, nil
 		}
 
@@ -434,6 +491,14 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 							Summary:  "Operation timed out",
 							Detail:   reason.Error(),
 						})
+				} else if reason, ok := err.(WaiterFailedError); ok {
+					resp.Diagnostics = append(resp.Diagnostics,
+						&tfprotov5.Diagnostic{
+							Severity:  tfprotov5.DiagnosticSeverityError,
+							Summary:   "Resource reached an error state while waiting",
+							Detail:    reason.Error(),
+							Attribute: tftypes.NewAttributePath().WithAttributeName("wait").WithElementKeyInt(0).WithAttributeName("error_on"),
+						})
 				} else {
 					resp.Diagnostics = append(resp.Diagnostics,
 						&tfprotov5.Diagnostic{
@@ -444,7 +509,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 					return resp, nil
 				}
 			}
-			r, err := rs.Get(ctx, rname, metav1.GetOptions{})
+			r, err := rs.Get(ctx, rname, metav1.GetOptions{}, subresources...)
 			if err != nil {
 				s.logger.Error("[ApplyResourceChange][ReadAfterWait]", "API error", dump(err), "API response", dump(result))
 				resp.Diagnostics = append(resp.Diagnostics,
@@ -458,7 +523,13 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			result = r
 		}
 
-		newResObject, err := payload.ToTFValue(RemoveServerSideFields(result.Object), tsch, th, tftypes.NewAttributePath())
+		var resObject map[string]interface{}
+		if subresource != "" {
+			resObject = subresourceObject(result.Object, gvk, subresource)
+		} else {
+			resObject = RemoveServerSideFields(result.Object)
+		}
+		newResObject, err := payload.ToTFValue(resObject, tsch, th, tftypes.NewAttributePath())
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics,
 				&tfprotov5.Diagnostic{
@@ -486,7 +557,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		resp.NewState = &newResState
 
 		// set resource identity data
-		idData, err := createIdentityData(result)
+		idData, err := createIdentityData(&unstructured.Unstructured{Object: resObject})
 		if err != nil {
 			return resp, err
 		}
@@ -505,6 +576,12 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			})
 			return resp, nil
 		}
+		if subresource, _ := getSubresource(priorStateVal); subresource != "" {
+			// the resource is not owned by Terraform, only its subresource
+			s.logger.Trace("[ApplyResourceChange][Delete]", "Leaving resource with applied subresource in place")
+			resp.NewState = req.PlannedState
+			return resp, nil
+		}
 		pco, ok := priorStateVal["object"]
 		if !ok {
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -549,7 +626,18 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 		ctxDeadline, cancel := context.WithDeadline(ctx, deadline)
 		defer cancel()
 
-		err = rs.Delete(ctxDeadline, rname, metav1.DeleteOptions{})
+		deleteOptions, err := getDeleteOptions(priorStateVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid delete options",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("delete_options"),
+			})
+			return resp, nil
+		}
+
+		err = rs.Delete(ctxDeadline, rname, deleteOptions)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				s.logger.Trace("[ApplyResourceChange][Delete]", "Resource is already deleted")
@@ -571,17 +659,22 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			return resp, nil
 		}
 		// wait for delete
+		var lastSeen *unstructured.Unstructured
 		for {
 			if time.Now().After(deadline) {
+				detail := "Deletion timed out. This can happen when there is a finalizer on a resource. You may need to delete this resource manually with kubectl."
+				if stuck := describeStuckDeletion(lastSeen); stuck != "" {
+					detail = fmt.Sprintf("%s\n\n%s", detail, stuck)
+				}
 				resp.Diagnostics = append(resp.Diagnostics,
 					&tfprotov5.Diagnostic{
 						Severity: tfprotov5.DiagnosticSeverityError,
 						Summary:  fmt.Sprintf("Timed out when waiting for resource %q to be deleted", rname),
-						Detail:   "Deletion timed out. This can happen when there is a finalizer on a resource. You may need to delete this resource manually with kubectl.",
+						Detail:   detail,
 					})
 				return resp, nil
 			}
-			_, err := rs.Get(ctxDeadline, rname, metav1.GetOptions{})
+			obj, err := rs.Get(ctxDeadline, rname, metav1.GetOptions{})
 			if err != nil {
 				if apierrors.IsNotFound(err) {
 					s.logger.Trace("[ApplyResourceChange][Delete]", "Resource is deleted")
@@ -595,6 +688,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 					})
 				return resp, nil
 			}
+			lastSeen = obj
 			time.Sleep(1 * time.Second) // lintignore:R018
 		}
 
@@ -629,3 +723,18 @@ func (s *RawProviderServer) getTimeouts(v map[string]tftypes.Value) map[string]s
 	}
 	return timeouts
 }
+
+// serverSideApply applies an object with server-side apply, as the field manager 'fieldManager'
+func serverSideApply(ctx context.Context, rs dynamic.ResourceInterface, obj *unstructured.Unstructured, fieldManager string, force bool, subresources ...string) (*unstructured.Unstructured, error) {
+	js, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return rs.Patch(ctx, obj.GetName(), types.ApplyPatchType, js,
+		metav1.PatchOptions{
+			FieldManager: fieldManager,
+			Force:        &force,
+		},
+		subresources...,
+	)
+}
@@ -0,0 +1,626 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const applySetResourceType = "kubernetes_applyset"
+
+var applySetParentGVR = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+
+// withApplySetLabel adds the ApplySet membership label to the labels of a planned object.
+// Labels that are still unknown are left alone, as they are filled in from the API server response during apply.
+func withApplySetLabel(obj tftypes.Value, id string) (tftypes.Value, error) {
+	if id == "" {
+		return obj, nil
+	}
+	labelsPath := tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("labels")
+	return tftypes.Transform(obj, func(ap *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !ap.Equal(labelsPath) || !v.IsKnown() || !v.Type().Is(tftypes.Map{}) {
+			return v, nil
+		}
+		labels := make(map[string]tftypes.Value)
+		if !v.IsNull() {
+			if err := v.As(&labels); err != nil {
+				return v, err
+			}
+		}
+		labels[util.ApplySetPartOfLabel] = tftypes.NewValue(v.Type().(tftypes.Map).ElementType, id)
+		return tftypes.NewValue(v.Type(), labels), nil
+	})
+}
+
+// setApplySetLabel adds the ApplySet membership label to an object in unstructured form
+func setApplySetLabel(obj map[string]interface{}, id string) {
+	if id == "" {
+		return
+	}
+	u := unstructured.Unstructured{Object: obj}
+	labels := u.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[util.ApplySetPartOfLabel] = id
+	u.SetLabels(labels)
+}
+
+// formatApplySetList formats a set of group-kinds or namespaces as the value of an ApplySet parent annotation
+func formatApplySetList(set map[string]bool) string {
+	items := make([]string, 0, len(set))
+	for k := range set {
+		if k != "" {
+			items = append(items, k)
+		}
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// parseApplySetList parses the value of a list-valued ApplySet parent annotation into a set
+func parseApplySetList(v string) map[string]bool {
+	set := make(map[string]bool)
+	for _, k := range strings.Split(v, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			set[k] = true
+		}
+	}
+	return set
+}
+
+// applySetParent builds the parent Secret of an ApplySet
+func applySetParent(name, namespace, id string, groupKinds, namespaces map[string]bool) *unstructured.Unstructured {
+	annotations := map[string]interface{}{
+		util.ApplySetToolingAnnotation: util.ApplySetTooling,
+		util.ApplySetGKsAnnotation:     formatApplySetList(groupKinds),
+	}
+	if additional := formatApplySetList(namespaces); additional != "" {
+		annotations[util.ApplySetAdditionalNamespacesAnnotation] = additional
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels": map[string]interface{}{
+				util.ApplySetParentIDLabel: id,
+			},
+			"annotations": annotations,
+		},
+	}}
+}
+
+func (s *RawProviderServer) applySetParentClient(namespace string) (dynamic.ResourceInterface, error) {
+	c, err := s.getDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.Resource(applySetParentGVR).Namespace(namespace), nil
+}
+
+func (s *RawProviderServer) validateApplySet(req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	config, err := req.Config.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	configVal := make(map[string]tftypes.Value)
+	if err := config.As(&configVal); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract resource state from SDK value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	members := configVal["members"]
+	if members.IsNull() || !members.IsKnown() {
+		return resp, nil
+	}
+	var mvals []tftypes.Value
+	if err := members.As(&mvals); err != nil {
+		return resp, nil
+	}
+	for i, mv := range mvals {
+		var atts map[string]tftypes.Value
+		if !mv.IsKnown() || mv.As(&atts) != nil {
+			continue
+		}
+		for _, k := range []string{"api_version", "kind", "name"} {
+			if v := atts[k]; v.IsKnown() && v.IsNull() {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "Invalid ApplySet member",
+					Detail:    fmt.Sprintf("The %q attribute of ApplySet members is required.", k),
+					Attribute: tftypes.NewAttributePath().WithAttributeName("members").WithElementKeyInt(i).WithAttributeName(k),
+				})
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (s *RawProviderServer) planApplySet(req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp := &tfprotov5.PlanResourceChangeResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine planned resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	proposedState, err := req.ProposedNewState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal planned resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	priorState, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal prior resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if proposedState.IsNull() {
+		// we plan to delete the resource
+		resp.PlannedState = req.ProposedNewState
+		return resp, nil
+	}
+	proposedVal := make(map[string]tftypes.Value)
+	err = proposedState.As(&proposedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract planned resource state from tftypes.Value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	// the ApplySet ID is derived from the identity of the parent object
+	name, namespace := proposedVal["name"], proposedVal["namespace"]
+	if name.IsKnown() && namespace.IsKnown() {
+		var n, ns string
+		name.As(&n)
+		namespace.As(&ns)
+		proposedVal["id"] = tftypes.NewValue(tftypes.String, util.ApplySetID(n, ns))
+	} else {
+		proposedVal["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	}
+
+	if !priorState.IsNull() {
+		priorVal := make(map[string]tftypes.Value)
+		if err := priorState.As(&priorVal); err == nil {
+			for _, k := range []string{"name", "namespace"} {
+				if !priorVal[k].Equal(proposedVal[k]) {
+					resp.RequiresReplace = append(resp.RequiresReplace, tftypes.NewAttributePath().WithAttributeName(k))
+				}
+			}
+		}
+	}
+
+	propStateVal := tftypes.NewValue(proposedState.Type(), proposedVal)
+	plannedState, err := tfprotov5.NewDynamicValue(propStateVal.Type(), propStateVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to assemble proposed state during plan",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	resp.PlannedState = &plannedState
+	return resp, nil
+}
+
+func (s *RawProviderServer) applyApplySet(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp := &tfprotov5.ApplyResourceChangeResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine planned resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	plannedState, err := req.PlannedState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal planned resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	priorState, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal prior resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	if plannedState.IsNull() {
+		// Delete the parent object only - the members are managed by their own resources
+		priorVal := make(map[string]tftypes.Value)
+		if err := priorState.As(&priorVal); err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to extract prior resource state values",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		var name, namespace string
+		priorVal["name"].As(&name)
+		priorVal["namespace"].As(&namespace)
+		rs, err := s.applySetParentClient(namespace)
+		if err == nil {
+			err = rs.Delete(ctx, name, metav1.DeleteOptions{})
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Failed to delete ApplySet parent %s/%s", namespace, name),
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		resp.NewState = req.PlannedState
+		return resp, nil
+	}
+
+	plannedVal := make(map[string]tftypes.Value)
+	err = plannedState.As(&plannedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract planned resource state values",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	var name, namespace string
+	plannedVal["name"].As(&name)
+	plannedVal["namespace"].As(&namespace)
+	id := util.ApplySetID(name, namespace)
+	parentName := types.NamespacedName{Namespace: namespace, Name: name}.String()
+
+	members, err := manifestsObjectsFromValue(plannedVal["members"])
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Failed to extract ApplySet members",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("members"),
+		})
+		return resp, nil
+	}
+
+	// members are identified by their resolved namespace, like the objects listed from the API server
+	memberKeys := make(map[string]bool, len(members))
+	groupKinds := make(map[string]bool)
+	namespaces := make(map[string]bool)
+	for _, o := range members {
+		_, ns, err := s.manifestsResourceClient(o)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// the kind isn't served, so there can't be any objects of it to keep
+				continue
+			}
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Failed to determine the type of %s", o),
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		o.namespace = ns
+		memberKeys[o.key()] = true
+		groupKinds[o.groupVersionKind().GroupKind().String()] = true
+		if ns != namespace {
+			namespaces[ns] = true
+		}
+	}
+
+	timeout, _ := time.ParseDuration(defaultUpdateTimeout)
+	if priorState.IsNull() {
+		timeout, _ = time.ParseDuration(defaultCreateTimeout)
+	}
+	ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rs, err := s.applySetParentClient(namespace)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to get dynamic client",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	// objects of the kinds and namespaces recorded previously need to be considered
+	// for pruning, even if the set no longer has any members of them
+	pruneGroupKinds := make(map[string]bool)
+	pruneNamespaces := map[string]bool{namespace: true}
+	existing, err := rs.Get(ctxDeadline, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		if t, ok := existing.GetAnnotations()[util.ApplySetToolingAnnotation]; ok && t != util.ApplySetTooling {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "ApplySet is managed by another tool",
+				Detail:   fmt.Sprintf("The ApplySet parent %s is managed by %q and cannot be managed by Terraform.", parentName, t),
+			})
+			return resp, nil
+		}
+		for gk := range parseApplySetList(existing.GetAnnotations()[util.ApplySetGKsAnnotation]) {
+			pruneGroupKinds[gk] = true
+		}
+		for ns := range parseApplySetList(existing.GetAnnotations()[util.ApplySetAdditionalNamespacesAnnotation]) {
+			pruneNamespaces[ns] = true
+		}
+	case !apierrors.IsNotFound(err):
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf("Failed to get ApplySet parent %s", parentName),
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	for gk := range groupKinds {
+		pruneGroupKinds[gk] = true
+	}
+	for ns := range namespaces {
+		pruneNamespaces[ns] = true
+	}
+
+	// record the superset of kinds and namespaces before pruning, so that an interrupted
+	// prune is resumed by the next apply
+	additional := make(map[string]bool, len(pruneNamespaces))
+	for ns := range pruneNamespaces {
+		if ns != namespace {
+			additional[ns] = true
+		}
+	}
+	if err := s.applyApplySetParent(ctxDeadline, rs, applySetParent(name, namespace, id, pruneGroupKinds, additional)); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, applyErrorToDiagnostics(err, parentName, nil, nil)...)
+		return resp, nil
+	}
+
+	stale, diags := s.listApplySetStaleObjects(ctxDeadline, id, pruneGroupKinds, pruneNamespaces, memberKeys)
+	resp.Diagnostics = append(resp.Diagnostics, diags...)
+	remaining, diags := s.deleteManifestsObjects(ctxDeadline, stale)
+	resp.Diagnostics = append(resp.Diagnostics, diags...)
+
+	if len(remaining) == 0 && len(resp.Diagnostics) == 0 {
+		if err := s.applyApplySetParent(ctxDeadline, rs, applySetParent(name, namespace, id, groupKinds, namespaces)); err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, applyErrorToDiagnostics(err, parentName, nil, nil)...)
+		}
+	}
+
+	plannedVal["id"] = tftypes.NewValue(tftypes.String, id)
+	newState, err := tfprotov5.NewDynamicValue(plannedState.Type(), tftypes.NewValue(plannedState.Type(), plannedVal))
+	if err != nil {
+		return resp, err
+	}
+	resp.NewState = &newState
+	return resp, nil
+}
+
+func (s *RawProviderServer) applyApplySetParent(ctx context.Context, rs dynamic.ResourceInterface, parent *unstructured.Unstructured) error {
+	js, err := json.Marshal(parent.Object)
+	if err != nil {
+		return err
+	}
+	s.logger.Trace("[ApplyResourceChange][kubernetes_applyset][API Payload]: %s", js)
+	_, err = rs.Patch(ctx, parent.GetName(), types.ApplyPatchType, js,
+		metav1.PatchOptions{
+			FieldManager: defaultFieldManagerName,
+		},
+	)
+	return err
+}
+
+// listApplySetStaleObjects lists the objects labelled as members of the ApplySet that are not declared as members anymore
+func (s *RawProviderServer) listApplySetStaleObjects(ctx context.Context, id string, groupKinds, namespaces map[string]bool, memberKeys map[string]bool) ([]manifestsObject, []*tfprotov5.Diagnostic) {
+	var diags []*tfprotov5.Diagnostic
+	c, err := s.getDynamicClient()
+	if err != nil {
+		return nil, append(diags, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to get dynamic client",
+			Detail:   err.Error(),
+		})
+	}
+	m, err := s.getRestMapper()
+	if err != nil {
+		return nil, append(diags, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to get RESTMapper client",
+			Detail:   err.Error(),
+		})
+	}
+	opts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", util.ApplySetPartOfLabel, id),
+	}
+
+	var stale []manifestsObject
+	collect := func(gvk schema.GroupVersionKind, l *unstructured.UnstructuredList) {
+		for _, item := range l.Items {
+			o := manifestsObject{
+				apiVersion: gvk.GroupVersion().String(),
+				kind:       gvk.Kind,
+				name:       item.GetName(),
+				namespace:  item.GetNamespace(),
+			}
+			if !memberKeys[o.key()] {
+				stale = append(stale, o)
+			}
+		}
+	}
+
+	for _, gk := range sortedKeys(groupKinds) {
+		mapping, err := m.RESTMapping(schema.ParseGroupKind(gk))
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// the kind isn't served anymore, and neither are its objects
+				continue
+			}
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Failed to determine the type of %s", gk),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			l, err := c.Resource(mapping.Resource).List(ctx, opts)
+			if err != nil {
+				diags = append(diags, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  fmt.Sprintf("Failed to list %s objects", gk),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			collect(mapping.GroupVersionKind, l)
+			continue
+		}
+		for _, ns := range sortedKeys(namespaces) {
+			l, err := c.Resource(mapping.Resource).Namespace(ns).List(ctx, opts)
+			if err != nil {
+				diags = append(diags, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  fmt.Sprintf("Failed to list %s objects in namespace %q", gk, ns),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			collect(mapping.GroupVersionKind, l)
+		}
+	}
+	return stale, diags
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *RawProviderServer) readApplySet(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	resp := &tfprotov5.ReadResourceResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	currentState, err := req.CurrentState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to decode current state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if currentState.IsNull() {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to read resource",
+			Detail:   "Incomplete of missing state",
+		})
+		return resp, nil
+	}
+	stateVal := make(map[string]tftypes.Value)
+	if err := currentState.As(&stateVal); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract resource from current state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	var name, namespace string
+	stateVal["name"].As(&name)
+	stateVal["namespace"].As(&namespace)
+
+	rs, err := s.applySetParentClient(namespace)
+	if err == nil {
+		_, err = rs.Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the parent is gone - the next plan will re-create it
+			return resp, nil
+		}
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf("Cannot GET ApplySet parent %s/%s", namespace, name),
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	resp.NewState = req.CurrentState
+	resp.Private = req.Private
+	return resp, nil
+}
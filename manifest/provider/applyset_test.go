@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+)
+
+func TestWithApplySetLabel(t *testing.T) {
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"metadata": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":   tftypes.String,
+			"labels": labelsType,
+		}},
+	}}
+	newObj := func(labels tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"metadata": tftypes.NewValue(objType.AttributeTypes["metadata"], map[string]tftypes.Value{
+				"name":   tftypes.NewValue(tftypes.String, "test"),
+				"labels": labels,
+			}),
+		})
+	}
+	withLabel := newObj(tftypes.NewValue(labelsType, map[string]tftypes.Value{
+		"app":                    tftypes.NewValue(tftypes.String, "test"),
+		util.ApplySetPartOfLabel: tftypes.NewValue(tftypes.String, "applyset-abc-v1"),
+	}))
+
+	samples := map[string]struct {
+		in  tftypes.Value
+		id  string
+		out tftypes.Value
+	}{
+		"known": {
+			in: newObj(tftypes.NewValue(labelsType, map[string]tftypes.Value{
+				"app": tftypes.NewValue(tftypes.String, "test"),
+			})),
+			id:  "applyset-abc-v1",
+			out: withLabel,
+		},
+		"null": {
+			in: newObj(tftypes.NewValue(labelsType, nil)),
+			id: "applyset-abc-v1",
+			out: newObj(tftypes.NewValue(labelsType, map[string]tftypes.Value{
+				util.ApplySetPartOfLabel: tftypes.NewValue(tftypes.String, "applyset-abc-v1"),
+			})),
+		},
+		"unknown": {
+			in:  newObj(tftypes.NewValue(labelsType, tftypes.UnknownValue)),
+			id:  "applyset-abc-v1",
+			out: newObj(tftypes.NewValue(labelsType, tftypes.UnknownValue)),
+		},
+		"no-applyset": {
+			in:  newObj(tftypes.NewValue(labelsType, nil)),
+			out: newObj(tftypes.NewValue(labelsType, nil)),
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			out, err := withApplySetLabel(s.in, s.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !out.Equal(s.out) {
+				t.Fatalf("expected %s, got %s", s.out, out)
+			}
+		})
+	}
+}
+
+func TestSetApplySetLabel(t *testing.T) {
+	obj := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+	}
+	setApplySetLabel(obj, "applyset-abc-v1")
+	labels := obj["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	if labels[util.ApplySetPartOfLabel] != "applyset-abc-v1" {
+		t.Fatalf("expected ApplySet label to be set, got %v", labels)
+	}
+}
+
+func TestApplySetParent(t *testing.T) {
+	gks := parseApplySetList("Deployment.apps, ConfigMap,,")
+	if len(gks) != 2 || !gks["Deployment.apps"] || !gks["ConfigMap"] {
+		t.Fatalf("unexpected group-kinds: %v", gks)
+	}
+
+	p := applySetParent("test", "default", "applyset-abc-v1", gks, map[string]bool{})
+	if p.GetLabels()[util.ApplySetParentIDLabel] != "applyset-abc-v1" {
+		t.Errorf("expected ApplySet ID label, got %v", p.GetLabels())
+	}
+	annotations := p.GetAnnotations()
+	if annotations[util.ApplySetGKsAnnotation] != "ConfigMap,Deployment.apps" {
+		t.Errorf("expected sorted group-kinds, got %q", annotations[util.ApplySetGKsAnnotation])
+	}
+	if annotations[util.ApplySetToolingAnnotation] != util.ApplySetTooling {
+		t.Errorf("expected tooling annotation, got %q", annotations[util.ApplySetToolingAnnotation])
+	}
+	if _, ok := annotations[util.ApplySetAdditionalNamespacesAnnotation]; ok {
+		t.Errorf("expected no additional namespaces annotation, got %v", annotations)
+	}
+
+	p = applySetParent("test", "default", "applyset-abc-v1", gks, map[string]bool{"kube-system": true, "apps": true})
+	if v := p.GetAnnotations()[util.ApplySetAdditionalNamespacesAnnotation]; v != "apps,kube-system" {
+		t.Errorf("expected additional namespaces annotation, got %q", v)
+	}
+}
@@ -5,18 +5,27 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 
@@ -40,6 +49,17 @@ func (ps *RawProviderServer) getDynamicClient() (dynamic.Interface, error) {
 	})
 }
 
+// getMetadataClient returns a configured client instance for the metadata of resources
+func (ps *RawProviderServer) getMetadataClient() (metadata.Interface, error) {
+	if ps.clientConfig == nil {
+		return nil, fmt.Errorf("cannot create metadata client: no client config")
+	}
+
+	return ps.metadataClient.Get(func() (metadata.Interface, error) {
+		return metadata.NewForConfig(ps.clientConfig)
+	})
+}
+
 // getDiscoveryClient returns a configured discovery client instance.
 func (ps *RawProviderServer) getDiscoveryClient() (discovery.DiscoveryInterface, error) {
 	if ps.clientConfig == nil {
@@ -80,21 +100,214 @@ func (ps *RawProviderServer) getOAPIv2Foundry() (openapi.Foundry, error) {
 	return ps.OAPIFoundry.Get(func() (openapi.Foundry, error) {
 		rc, err := ps.getRestClient()
 		if err != nil {
-			return nil, fmt.Errorf("failed get OpenAPI spec: %s", err)
+			return nil, fmt.Errorf("failed get OpenAPI spec: %w", err)
+		}
+
+		if sc := ps.getSpecCache(); sc != nil {
+			rs, etag, err := ps.getCachedOAPIv2Spec(rc, sc)
+			if err != nil {
+				return nil, fmt.Errorf("failed get OpenAPI spec: %w", err)
+			}
+			if etag != "" {
+				// the spec is only parsed when a type was not derived from this version of the spec before
+				return openapi.NewCachedFoundry(sc, "openapi/v2/types/", etag, func() (openapi.Foundry, error) {
+					return newOAPIv2Foundry(rs)
+				}), nil
+			}
+			return newOAPIv2Foundry(rs)
 		}
 
 		rq := rc.Verb("GET").Timeout(30*time.Second).AbsPath("openapi", "v2")
 		rs, err := rq.DoRaw(context.TODO())
 		if err != nil {
-			return nil, fmt.Errorf("failed get OpenAPI spec: %s", err)
+			return nil, fmt.Errorf("failed get OpenAPI spec: %w", err)
+		}
+		return newOAPIv2Foundry(rs)
+	})
+}
+
+func newOAPIv2Foundry(spec []byte) (openapi.Foundry, error) {
+	oapif, err := openapi.NewFoundryFromSpecV2(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed construct OpenAPI foundry: %s", err)
+	}
+	return oapif, nil
+}
+
+// getOAPIv3Foundry returns an interface to request tftype types from the OpenAPIv3 spec of the cluster.
+// Documents are fetched per group-version from /openapi/v3/apis/<group>/<version>, only when a type
+// from that group-version is first requested.
+func (ps *RawProviderServer) getOAPIv3Foundry() (openapi.Foundry, error) {
+	return ps.OAPIv3Foundry.Get(func() (openapi.Foundry, error) {
+		dc, err := ps.getDiscoveryClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed get OpenAPI v3 spec: %w", err)
+		}
+
+		paths, err := dc.OpenAPIV3().Paths()
+		if err != nil {
+			return nil, fmt.Errorf("failed get OpenAPI v3 spec: %w", err)
+		}
+		if len(paths) == 0 {
+			return nil, errors.New("API server does not publish OpenAPI v3 documents")
+		}
+
+		sc := ps.getSpecCache()
+		return openapi.NewFoundryFromGroupVersionSpecsV3(func(gv schema.GroupVersion) ([]byte, error) {
+			p := "apis/" + gv.Group + "/" + gv.Version
+			if gv.Group == "" {
+				p = "api/" + gv.Version
+			}
+			gvs, ok := paths[p]
+			if !ok {
+				return nil, fmt.Errorf("no OpenAPI v3 document published for %q", gv.String())
+			}
+			if sc == nil {
+				return gvs.Schema(runtime.ContentTypeJSON)
+			}
+			// the document URL carries a hash of its content, which changes whenever the document does
+			key := "openapi/v3/" + p
+			if spec, validator, ok := sc.Get(key); ok && validator == gvs.ServerRelativeURL() {
+				return spec, nil
+			}
+			spec, err := gvs.Schema(runtime.ContentTypeJSON)
+			if err != nil {
+				return nil, err
+			}
+			if err := sc.Put(key, gvs.ServerRelativeURL(), spec); err != nil {
+				ps.logger.Warn("[getOAPIv3Foundry]", "failed to cache OpenAPI document", err.Error())
+			}
+			return spec, nil
+		}), nil
+	})
+}
+
+// getSpecCache returns the on-disk cache of OpenAPI documents for the configured API server,
+// or nil when 'openapi_cache_dir' is not configured or the cache can't be used.
+func (ps *RawProviderServer) getSpecCache() *openapi.SpecCache {
+	if ps.openapiCacheDir == "" {
+		return nil
+	}
+	sc, err := ps.specCache.Get(func() (*openapi.SpecCache, error) {
+		dc, err := ps.getDiscoveryClient()
+		if err != nil {
+			return nil, err
+		}
+		sv, err := dc.ServerVersion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine API server version: %w", err)
 		}
+		return openapi.NewSpecCache(ps.openapiCacheDir, ps.clientConfig.Host, sv.GitVersion)
+	})
+	if err != nil {
+		ps.logger.Warn("[getSpecCache]", "OpenAPI cache disabled", err.Error())
+		return nil
+	}
+	return sc
+}
 
-		oapif, err := openapi.NewFoundryFromSpecV2(rs)
+// getCachedOAPIv2Spec retrieves the OpenAPI v2 spec, reusing the cached copy
+// when the API server confirms it is still current via its ETag.
+// It returns the spec along with its ETag, which is empty when the API server doesn't send one.
+func (ps *RawProviderServer) getCachedOAPIv2Spec(rc rest.Interface, sc *openapi.SpecCache) ([]byte, string, error) {
+	const key = "openapi/v2"
+
+	// the HTTP client of the REST client is used directly, as REST requests don't expose the response headers
+	client, ok := rc.(*rest.RESTClient)
+	if !ok || client.Client == nil {
+		return nil, "", fmt.Errorf("unexpected REST client type %T", rc)
+	}
+	u := rc.Get().AbsPath("openapi", "v2").URL().String()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	get := func(etag string) (*http.Response, error) {
+		rq, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed construct OpenAPI foundry: %s", err)
+			return nil, err
+		}
+		rq.Header.Set("Accept", runtime.ContentTypeJSON)
+		if etag != "" {
+			rq.Header.Set("If-None-Match", etag)
+		}
+		return client.Client.Do(rq)
+	}
+
+	cached, etag, ok := sc.Get(key)
+	rs, err := get(etag)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rs.Body.Close()
+
+	if rs.StatusCode == http.StatusNotModified {
+		if ok {
+			return cached, etag, nil
+		}
+		// the cache entry is gone, so the spec has to be requested in full
+		rs.Body.Close()
+		rs, err = get("")
+		if err != nil {
+			return nil, "", err
+		}
+		defer rs.Body.Close()
+	}
+	if rs.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected response status: %s", rs.Status)
+	}
+	spec, err := io.ReadAll(rs.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	etag = rs.Header.Get("ETag")
+	if etag != "" {
+		if err := sc.Put(key, etag, spec); err != nil {
+			ps.logger.Warn("[getCachedOAPIv2Spec]", "failed to cache OpenAPI spec", err.Error())
 		}
+	}
+	return spec, etag, nil
+}
+
+// getOAPIFoundry returns an interface to request tftype types of built-in resources from the cluster.
+// OpenAPI v3 documents are preferred, falling back to the OpenAPI v2 spec on API servers
+// that don't serve v3 or when a type is missing from the v3 documents.
+func (ps *RawProviderServer) getOAPIFoundry() (openapi.Foundry, error) {
+	v3, err := ps.getOAPIv3Foundry()
+	if err != nil {
+		ps.logger.Debug("[getOAPIFoundry]", "OpenAPI v3 not available, using v2", err.Error())
+		return ps.getOAPIv2Foundry()
+	}
+	return &fallbackFoundry{primary: v3, fallback: ps.getOAPIv2Foundry}, nil
+}
 
-		return oapif, nil
+// fallbackFoundry looks up types in the primary foundry first and
+// only consults the fallback foundry if that fails
+type fallbackFoundry struct {
+	primary  openapi.Foundry
+	fallback func() (openapi.Foundry, error)
+}
+
+func (f *fallbackFoundry) GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[string]string, error) {
+	t, hints, err := f.primary.GetTypeByGVK(gvk)
+	if err == nil {
+		return t, hints, nil
+	}
+	fb, ferr := f.fallback()
+	if ferr != nil {
+		return nil, nil, fmt.Errorf("%s (fallback: %w)", err, ferr)
+	}
+	return fb.GetTypeByGVK(gvk)
+}
+
+// getOfflineOAPIFoundry returns an interface to request tftype types from the OpenAPI spec
+// bundled with the provider for the Kubernetes version set in 'offline_schema_version'
+func (ps *RawProviderServer) getOfflineOAPIFoundry() (openapi.Foundry, error) {
+	if ps.offlineSchemaVersion == "" {
+		return nil, errors.New("no offline schema version configured")
+	}
+
+	return ps.offlineOAPIFoundry.Get(func() (openapi.Foundry, error) {
+		return openapi.NewFoundryFromBundledSpec(ps.offlineSchemaVersion)
 	})
 }
 
@@ -111,8 +324,8 @@ type loggingRountTripper struct {
 }
 
 func (t *loggingRountTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	if req.URL.Path == "/openapi/v2" {
-		// don't trace-log the OpenAPI spec document, it's really big
+	if strings.HasPrefix(req.URL.Path, "/openapi/") {
+		// don't trace-log the OpenAPI spec documents, they're really big
 		return t.ot.RoundTrip(req)
 	}
 	return t.lt.RoundTrip(req)
@@ -140,6 +353,7 @@ func (ps *RawProviderServer) checkValidCredentials(ctx context.Context) []*tfpro
 					Detail:   fmt.Sprintf("The credentials configured in the provider block are not accepted by the API server. Error: %s\n\nSet TF_LOG=debug and look for '[InvalidClientConfiguration]' in the log to see actual configuration.", rs.Error().Error()),
 				})
 			default:
+				ps.apiServerUnreachable = isAPIServerUnreachable(rs.Error())
 				diags = append(diags, &tfprotov5.Diagnostic{
 					Severity: tfprotov5.DiagnosticSeverityError,
 					Summary:  "Invalid configuration for API client",
@@ -153,3 +367,17 @@ func (ps *RawProviderServer) checkValidCredentials(ctx context.Context) []*tfpro
 
 	return diagnostics
 }
+
+// isAPIServerUnreachable checks if an error results from failing to connect to the API server,
+// as happens when the cluster hasn't been created yet: the connection is refused, or its address doesn't resolve.
+// Timeouts are not included, as they also happen with a slow or overloaded API server.
+func isAPIServerUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if utilnet.IsConnectionRefused(err) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
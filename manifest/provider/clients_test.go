@@ -0,0 +1,134 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+func TestIsAPIServerUnreachable(t *testing.T) {
+	samples := map[string]struct {
+		err  error
+		want bool
+	}{
+		"nil": {
+			err:  nil,
+			want: false,
+		},
+		"connection refused": {
+			err: &url.Error{Op: "Get", URL: "https://127.0.0.1:6443/apis", Err: &net.OpError{
+				Op:  "dial",
+				Net: "tcp",
+				Err: syscall.ECONNREFUSED,
+			}},
+			want: true,
+		},
+		"unresolved host": {
+			err: fmt.Errorf("failed get OpenAPI spec: %w", &url.Error{Op: "Get", URL: "https://cluster.example.com/openapi/v2", Err: &net.DNSError{
+				Err:        "no such host",
+				Name:       "cluster.example.com",
+				IsNotFound: true,
+			}}),
+			want: true,
+		},
+		"timeout": {
+			err: &url.Error{Op: "Get", URL: "https://127.0.0.1:6443/apis", Err: &net.OpError{
+				Op:  "read",
+				Net: "tcp",
+				Err: os.ErrDeadlineExceeded,
+			}},
+			want: false,
+		},
+		"other error": {
+			err:  errors.New("the server could not find the requested resource"),
+			want: false,
+		},
+	}
+
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			if got := isAPIServerUnreachable(s.err); got != s.want {
+				t.Fatalf("expected %t, got %t", s.want, got)
+			}
+		})
+	}
+}
+
+func TestGetCachedOAPIv2Spec(t *testing.T) {
+	const etag = `"ABCDEF"`
+	spec := []byte(`{"swagger": "2.0"}`)
+	full := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openapi/v2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", etag)
+		w.Write(spec)
+	}))
+	defer srv.Close()
+
+	codec := runtime.NoopEncoder{Decoder: scheme.Codecs.UniversalDecoder()}
+	cfg := &rest.Config{Host: srv.URL}
+	cfg.NegotiatedSerializer = serializer.NegotiatedSerializerWrapper(runtime.SerializerInfo{Serializer: codec})
+	rc, err := rest.UnversionedRESTClientFor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	sc, err := openapi.NewSpecCache(dir, srv.URL, "v1.32.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := &RawProviderServer{logger: hclog.NewNullLogger()}
+
+	for i := 0; i < 2; i++ {
+		rs, validator, err := ps.getCachedOAPIv2Spec(rc, sc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(rs) != string(spec) || validator != etag {
+			t.Fatalf("unexpected spec %q with ETag %q", rs, validator)
+		}
+	}
+	if full != 1 {
+		t.Fatalf("expected the spec to be downloaded once, got %d downloads", full)
+	}
+
+	// a spec missing from the cache is downloaded again
+	entries, err := filepath.Glob(filepath.Join(dir, "*", "*", "*.json"))
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("expected a cached spec, got %v (%v)", entries, err)
+	}
+	for _, e := range entries {
+		os.Remove(e)
+	}
+	if _, _, err := ps.getCachedOAPIv2Spec(rc, sc); err != nil {
+		t.Fatal(err)
+	}
+	if full != 2 {
+		t.Fatalf("expected the spec to be downloaded again, got %d downloads", full)
+	}
+}
@@ -8,14 +8,18 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
 	"github.com/mitchellh/go-homedir"
 	"golang.org/x/mod/semver"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,6 +71,120 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		return response, nil
 	}
 
+	// Handle 'offline_schema_version' attribute
+	//
+	var offlineSchemaVersion string
+	if !providerConfig["offline_schema_version"].IsNull() && providerConfig["offline_schema_version"].IsKnown() {
+		err = providerConfig["offline_schema_version"].As(&offlineSchemaVersion)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'offline_schema_version' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+	} else if offlineSchemaVersionEnv, ok := os.LookupEnv("KUBE_OFFLINE_SCHEMA_VERSION"); ok && offlineSchemaVersionEnv != "" {
+		offlineSchemaVersion = offlineSchemaVersionEnv
+	}
+	if len(offlineSchemaVersion) > 0 {
+		if !openapi.HasBundledSpec(offlineSchemaVersion) {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'offline_schema_version' has no bundled OpenAPI schema for Kubernetes version %q. Supported versions are: %s", offlineSchemaVersion, strings.Join(openapi.BundledSpecVersions(), ", ")),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("offline_schema_version"),
+			})
+			return response, nil
+		}
+		s.offlineSchemaVersion = offlineSchemaVersion
+	}
+
+	// Handle 'openapi_cache_dir' attribute
+	//
+	var openapiCacheDir string
+	if !providerConfig["openapi_cache_dir"].IsNull() && providerConfig["openapi_cache_dir"].IsKnown() {
+		err = providerConfig["openapi_cache_dir"].As(&openapiCacheDir)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'openapi_cache_dir' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+	} else if openapiCacheDirEnv, ok := os.LookupEnv("KUBE_OPENAPI_CACHE_DIR"); ok && openapiCacheDirEnv != "" {
+		openapiCacheDir = openapiCacheDirEnv
+	}
+	if len(openapiCacheDir) > 0 {
+		openapiCacheDir, err = homedir.Expand(openapiCacheDir)
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'openapi_cache_dir' cannot be expanded: %s", err),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("openapi_cache_dir"),
+			})
+			return response, nil
+		}
+		s.openapiCacheDir = openapiCacheDir
+	}
+
+	// Handle 'applyset_parent' attribute
+	//
+	var applySetParent string
+	if !providerConfig["applyset_parent"].IsNull() && providerConfig["applyset_parent"].IsKnown() {
+		err = providerConfig["applyset_parent"].As(&applySetParent)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'applyset_parent' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+	} else if applySetParentEnv, ok := os.LookupEnv("KUBE_APPLYSET_PARENT"); ok && applySetParentEnv != "" {
+		applySetParent = applySetParentEnv
+	}
+	if len(applySetParent) > 0 {
+		name, namespace, err := util.ParseApplySetParent(applySetParent)
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("applyset_parent"),
+			})
+			return response, nil
+		}
+		s.applySetID = util.ApplySetID(name, namespace)
+	}
+
+	// Handle 'default_annotations' and 'default_labels' attributes
+	//
+	for _, a := range []string{"default_annotations", "default_labels"} {
+		if providerConfig[a].IsNull() || !providerConfig[a].IsKnown() {
+			continue
+		}
+		m, err := stringMapValue(providerConfig[a])
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Provider configuration: failed to assert type of '%s' value", a),
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+		if a == "default_annotations" {
+			s.defaultAnnotations = m
+		} else {
+			s.defaultLabels = m
+		}
+	}
+
 	overrides := &clientcmd.ConfigOverrides{}
 	loader := &clientcmd.ClientConfigLoadingRules{}
 
@@ -142,6 +260,44 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		loader.Precedence = precedence
 	}
 
+	// Handle 'config_raw' attribute
+	//
+	var configRaw string
+	if !providerConfig["config_raw"].IsNull() && providerConfig["config_raw"].IsKnown() {
+		err = providerConfig["config_raw"].As(&configRaw)
+		if err != nil {
+			// invalid attribute - this shouldn't happen, bail out now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to extract 'config_raw' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+	} else if configRawEnv, ok := os.LookupEnv("KUBE_CONFIG_RAW"); ok && configRawEnv != "" {
+		configRaw = configRawEnv
+	}
+	if !providerConfig["config_raw"].IsNull() && (!providerConfig["config_path"].IsNull() || !providerConfig["config_paths"].IsNull()) {
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid attribute in provider configuration",
+			Detail:    "'config_raw' conflicts with 'config_path' and 'config_paths': only one way of loading the kube config can be set.",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("config_raw"),
+		})
+	}
+	var rawConfig *clientcmdapi.Config
+	if len(configRaw) > 0 {
+		rawConfig, err = clientcmd.Load([]byte(configRaw))
+		if err != nil {
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'config_raw' is not a valid kube config: %v", err),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("config_raw"),
+			})
+		}
+	}
+
 	// Handle 'client_certificate' attribute
 	//
 	var clientCertificate string
@@ -571,10 +727,265 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		}
 	}
 
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	if !providerConfig["impersonate"].IsNull() && providerConfig["impersonate"].IsKnown() {
+		var impersonateBlock []tftypes.Value
+		err = providerConfig["impersonate"].As(&impersonateBlock)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'impersonate' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+		if len(impersonateBlock) > 0 {
+			var impersonateObj map[string]tftypes.Value
+			err := impersonateBlock[0].As(&impersonateObj)
+			if err != nil {
+				response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  `Provider configuration: failed to assert type of "impersonate" block`,
+					Detail:   err.Error(),
+				})
+				return response, nil
+			}
+			if !impersonateObj["user"].IsNull() && impersonateObj["user"].IsKnown() {
+				var user string
+				err = impersonateObj["user"].As(&user)
+				if err != nil {
+					// invalid attribute type - this shouldn't happen, bail out for now
+					response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Provider configuration: failed to assert type of 'user' value",
+						Detail:   err.Error(),
+					})
+					return response, nil
+				}
+				overrides.AuthInfo.Impersonate = user
+			}
+			if !impersonateObj["uid"].IsNull() && impersonateObj["uid"].IsKnown() {
+				var uid string
+				err = impersonateObj["uid"].As(&uid)
+				if err != nil {
+					// invalid attribute type - this shouldn't happen, bail out for now
+					response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Provider configuration: failed to assert type of 'uid' value",
+						Detail:   err.Error(),
+					})
+					return response, nil
+				}
+				overrides.AuthInfo.ImpersonateUID = uid
+			}
+			if !impersonateObj["groups"].IsNull() && impersonateObj["groups"].IsFullyKnown() {
+				groups, err := stringListValue(impersonateObj["groups"])
+				if err != nil {
+					// invalid attribute type - this shouldn't happen, bail out for now
+					response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Provider configuration: failed to assert type of 'groups' value",
+						Detail:   err.Error(),
+					})
+					return response, nil
+				}
+				overrides.AuthInfo.ImpersonateGroups = groups
+			}
+			if !impersonateObj["extra"].IsNull() && impersonateObj["extra"].IsFullyKnown() {
+				var extraBlocks []tftypes.Value
+				err = impersonateObj["extra"].As(&extraBlocks)
+				if err != nil {
+					// invalid attribute type - this shouldn't happen, bail out for now
+					response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  `Provider configuration: failed to assert type of "extra" block`,
+						Detail:   err.Error(),
+					})
+					return response, nil
+				}
+				for _, eb := range extraBlocks {
+					var extraObj map[string]tftypes.Value
+					var key string
+					err := eb.As(&extraObj)
+					if err == nil {
+						err = extraObj["key"].As(&key)
+					}
+					var values []string
+					if err == nil {
+						values, err = stringListValue(extraObj["values"])
+					}
+					if err != nil {
+						// invalid attribute type - this shouldn't happen, bail out for now
+						response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+							Severity: tfprotov5.DiagnosticSeverityError,
+							Summary:  `Provider configuration: failed to assert type of "extra" block`,
+							Detail:   err.Error(),
+						})
+						return response, nil
+					}
+					if overrides.AuthInfo.ImpersonateUserExtra == nil {
+						overrides.AuthInfo.ImpersonateUserExtra = make(map[string][]string)
+					}
+					overrides.AuthInfo.ImpersonateUserExtra[key] = append(overrides.AuthInfo.ImpersonateUserExtra[key], values...)
+				}
+			}
+		}
+	}
+
+	// Handle 'qps' attribute
+	//
+	var qps float64
+	if !providerConfig["qps"].IsNull() && providerConfig["qps"].IsKnown() {
+		var f big.Float
+		err = providerConfig["qps"].As(&f)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'qps' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+		qps, _ = f.Float64()
+	} else if qpsEnv, ok := os.LookupEnv("KUBE_QPS"); ok && qpsEnv != "" {
+		qps, err = strconv.ParseFloat(qpsEnv, 32)
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Invalid provider configuration",
+				Detail:   "Environment variable KUBE_QPS contains invalid value: " + err.Error(),
+			})
+			return response, nil
+		}
+	}
+
+	// Handle 'burst' attribute
+	//
+	var burst int64
+	if !providerConfig["burst"].IsNull() && providerConfig["burst"].IsKnown() {
+		var f big.Float
+		err = providerConfig["burst"].As(&f)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'burst' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+		if !f.IsInt() || f.Sign() < 0 {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'burst' must be a positive whole number, got %s", f.String()),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("burst"),
+			})
+			return response, nil
+		}
+		burst, _ = f.Int64()
+	} else if burstEnv, ok := os.LookupEnv("KUBE_BURST"); ok && burstEnv != "" {
+		burst, err = strconv.ParseInt(burstEnv, 10, 32)
+		if err == nil && burst < 0 {
+			err = fmt.Errorf("%d is negative", burst)
+		}
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Invalid provider configuration",
+				Detail:   "Environment variable KUBE_BURST contains invalid value: " + err.Error(),
+			})
+			return response, nil
+		}
+	}
+
+	// Handle 'request_timeout' attribute
+	//
+	var requestTimeout string
+	if !providerConfig["request_timeout"].IsNull() && providerConfig["request_timeout"].IsKnown() {
+		err = providerConfig["request_timeout"].As(&requestTimeout)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'request_timeout' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+	} else if requestTimeoutEnv, ok := os.LookupEnv("KUBE_REQUEST_TIMEOUT"); ok && requestTimeoutEnv != "" {
+		requestTimeout = requestTimeoutEnv
+	}
+	var timeout time.Duration
+	if requestTimeout != "" {
+		timeout, err = time.ParseDuration(requestTimeout)
+		if err == nil && timeout < 0 {
+			err = fmt.Errorf("%s is negative", requestTimeout)
+		}
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'request_timeout' must be a duration, like \"30s\" or \"2m\": %s", err),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("request_timeout"),
+			})
+			return response, nil
+		}
+	}
+
+	// Handle 'max_retries' attribute
+	//
+	maxRetries := int64(util.DefaultMaxRetries)
+	if !providerConfig["max_retries"].IsNull() && providerConfig["max_retries"].IsKnown() {
+		var f big.Float
+		err = providerConfig["max_retries"].As(&f)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'max_retries' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+		if !f.IsInt() || f.Sign() < 0 {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid attribute in provider configuration",
+				Detail:    fmt.Sprintf("'max_retries' must be a non-negative whole number, got %s", f.String()),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("max_retries"),
+			})
+			return response, nil
+		}
+		maxRetries, _ = f.Int64()
+	} else if maxRetriesEnv, ok := os.LookupEnv("KUBE_MAX_RETRIES"); ok && maxRetriesEnv != "" {
+		maxRetries, err = strconv.ParseInt(maxRetriesEnv, 10, 32)
+		if err == nil && maxRetries < 0 {
+			err = fmt.Errorf("%d is negative", maxRetries)
+		}
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Invalid provider configuration",
+				Detail:   "Environment variable KUBE_MAX_RETRIES contains invalid value: " + err.Error(),
+			})
+			return response, nil
+		}
+	}
+
+	var cc clientcmd.ClientConfig
+	if rawConfig != nil {
+		// the kube config given inline takes precedence over the kube config files
+		cc = clientcmd.NewNonInteractiveClientConfig(*rawConfig, overrides.CurrentContext, overrides, nil)
+	} else {
+		cc = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
+	}
 	clientConfig, err := cc.ClientConfig()
 	if err != nil {
-		s.logger.Error("[Configure]", "Failed to load config:", dump(cc))
+		if rawConfig == nil {
+			s.logger.Error("[Configure]", "Failed to load config:", dump(cc))
+		}
 		if errors.Is(err, clientcmd.ErrEmptyConfig) {
 			// this is a terrible fix for if the configuration is a calculated value
 			return response, nil
@@ -587,9 +998,15 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		return response, nil
 	}
 
+	clientConfig.QPS = float32(qps)
+	clientConfig.Burst = int(burst)
+	clientConfig.Timeout = timeout
+
 	if s.logger.IsTrace() {
 		clientConfig.WrapTransport = loggingTransport
 	}
+	// transient errors are retried around the logging transport, so that each attempt is logged
+	clientConfig.Wrap(util.RetryTransport(int(maxRetries)))
 
 	codec := runtime.NoopEncoder{Decoder: scheme.Codecs.UniversalDecoder()}
 	clientConfig.NegotiatedSerializer = serializer.NegotiatedSerializerWrapper(runtime.SerializerInfo{Serializer: codec})
@@ -610,3 +1027,37 @@ func (s *RawProviderServer) canExecute() (resp []*tfprotov5.Diagnostic) {
 	}
 	return
 }
+
+// stringListValue converts a list of strings from the provider configuration
+func stringListValue(v tftypes.Value) ([]string, error) {
+	var elems []tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(elems))
+	for _, e := range elems {
+		var s string
+		if err := e.As(&s); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// stringMapValue converts a map of strings from the provider configuration
+func stringMapValue(v tftypes.Value) (map[string]string, error) {
+	var elems map[string]tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(elems))
+	for k, e := range elems {
+		var s string
+		if err := e.As(&s); err != nil {
+			return nil, err
+		}
+		out[k] = s
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`
+
+func TestConfigureProviderConfigRawConflicts(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(configPath, []byte(testKubeConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfgType := GetObjectTypeFromSchema(GetProviderConfigSchema()).(tftypes.Object)
+	pathsType := cfgType.AttributeTypes["config_paths"]
+
+	samples := map[string]struct {
+		config   map[string]tftypes.Value
+		conflict bool
+	}{
+		"config_raw": {
+			config: map[string]tftypes.Value{
+				"config_raw": tftypes.NewValue(tftypes.String, testKubeConfig),
+			},
+		},
+		"config_raw and config_path": {
+			config: map[string]tftypes.Value{
+				"config_raw":  tftypes.NewValue(tftypes.String, testKubeConfig),
+				"config_path": tftypes.NewValue(tftypes.String, configPath),
+			},
+			conflict: true,
+		},
+		"config_raw and config_paths": {
+			config: map[string]tftypes.Value{
+				"config_raw": tftypes.NewValue(tftypes.String, testKubeConfig),
+				"config_paths": tftypes.NewValue(pathsType, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, configPath),
+				}),
+			},
+			conflict: true,
+		},
+	}
+	for name, sample := range samples {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{"KUBE_CONFIG_PATH", "KUBE_CONFIG_PATHS", "KUBE_CONFIG_RAW"} {
+				t.Setenv(env, "")
+			}
+			cfg, err := tfprotov5.NewDynamicValue(cfgType, objectWithNulls(cfgType, sample.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &RawProviderServer{logger: hclog.NewNullLogger()}
+			resp, err := s.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+				TerraformVersion: "1.9.0",
+				Config:           &cfg,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var conflict bool
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov5.DiagnosticSeverityError && d.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName("config_raw")) {
+					conflict = true
+				}
+			}
+			if conflict != sample.conflict {
+				t.Fatalf("expected conflict %t, got diagnostics %v", sample.conflict, resp.Diagnostics)
+			}
+		})
+	}
+}
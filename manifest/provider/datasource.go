@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -18,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 )
 
 func (s *RawProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
@@ -139,27 +141,104 @@ func (s *RawProviderServer) ReadPluralDataSource(ctx context.Context, req *tfpro
 	var labelSelector, fieldSelector string
 	dsConfig["label_selector"].As(&labelSelector)
 	dsConfig["field_selector"].As(&fieldSelector)
-	var limit big.Float
+	var limit, pageSize big.Float
 	dsConfig["limit"].As(&limit)
 	lim, _ := limit.Int64()
+	dsConfig["page_size"].As(&pageSize)
+	ps, _ := pageSize.Int64()
+	if lim < 0 || ps < 0 {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Invalid list options",
+			Detail:   "The values of 'limit' and 'page_size' cannot be negative.",
+		})
+		return resp, nil
+	}
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
 		FieldSelector: fieldSelector,
-		Limit:         lim,
 	}
 
-	var res *unstructured.UnstructuredList
+	var namespace string
+	var allNamespaces bool
+	dsConfig["namespace"].As(&namespace)
+	dsConfig["all_namespaces"].As(&allNamespaces)
+	if allNamespaces && namespace != "" {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Conflicting namespace options",
+			Detail:    "Only one of 'namespace' and 'all_namespaces' can be set.",
+			Attribute: tftypes.NewAttributePath().WithAttributeName("all_namespaces"),
+		})
+		return resp, nil
+	}
 
-	if ns {
-		var namespace string
-		dsConfig["namespace"].As(&namespace)
-		if namespace == "" {
-			namespace = "default"
+	var metadataOnly bool
+	var fields []tftypes.Value
+	dsConfig["metadata_only"].As(&metadataOnly)
+	dsConfig["fields"].As(&fields)
+	paths := make([]string, 0, len(fields))
+	for _, f := range fields {
+		var path string
+		f.As(&path)
+		paths = append(paths, path)
+	}
+	if metadataOnly {
+		metadataProjection, err := newProjection(metadataFields)
+		if err == nil {
+			objectType, err = metadataProjection.Type(objectType, tftypes.NewAttributePath())
 		}
-		res, err = rcl.Namespace(namespace).List(ctx, listOptions)
-	} else {
-		res, err = rcl.List(ctx, listOptions)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to determine the type of the resource metadata",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+	}
+	var project projection
+	if len(paths) > 0 {
+		project, err = newProjection(paths)
+		if err == nil {
+			objectType, err = project.Type(objectType, tftypes.NewAttributePath())
+		}
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid field selection",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("fields"),
+			})
+			return resp, nil
+		}
+	}
+
+	if ns && !allNamespaces && namespace == "" {
+		namespace = "default"
+	}
+	var rs lister = rcl
+	if ns && !allNamespaces {
+		rs = rcl.Namespace(namespace)
+	}
+	if metadataOnly {
+		mcl, err := s.getMetadataClient()
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to get metadata client",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		ml := metadataLister{resource: mcl.Resource(gvr), gvk: gvk}
+		if ns && !allNamespaces {
+			ml.resource = mcl.Resource(gvr).Namespace(namespace)
+		}
+		rs = ml
 	}
+
+	res, err := listPages(ctx, rs, listOptions, ps, lim)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return resp, nil
@@ -169,12 +248,18 @@ func (s *RawProviderServer) ReadPluralDataSource(ctx context.Context, req *tfpro
 			Summary:  "Failed to get data source",
 			Detail:   err.Error(),
 		}
+		if apierrors.IsResourceExpired(err) {
+			d.Detail = fmt.Sprintf("%s\n\nThe list changed too much while it was being paged through. Try again, or increase 'page_size'.", err)
+		}
 		resp.Diagnostics = append(resp.Diagnostics, &d)
 		return resp, nil
 	}
 
 	listObjects := []tftypes.Value{}
 	for _, item := range res.Items {
+		if project != nil {
+			item.Object = project.Object(item.Object)
+		}
 		nobj, err := payload.ToTFValue(item.Object, objectType, th, tftypes.NewAttributePath())
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -216,6 +301,11 @@ func (s *RawProviderServer) ReadPluralDataSource(ctx context.Context, req *tfpro
 		return resp, nil
 	}
 	rawState["objects"] = morph.UnknownToNull(tuple)
+	rawState["resource_version"] = tftypes.NewValue(tftypes.String, res.GetResourceVersion())
+	rawState["remaining_item_count"] = tftypes.NewValue(tftypes.Number, nil)
+	if rc := res.GetRemainingItemCount(); rc != nil {
+		rawState["remaining_item_count"] = tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(*rc))
+	}
 
 	v := tftypes.NewValue(rt, rawState)
 	state, err := tfprotov5.NewDynamicValue(v.Type(), v)
@@ -340,16 +430,59 @@ func (s *RawProviderServer) ReadSingularDataSource(ctx context.Context, req *tfp
 	var name string
 	metadata["name"].As(&name)
 
-	var res *unstructured.Unstructured
+	var rs dynamic.ResourceInterface = rcl
 	if ns {
 		var namespace string
 		metadata["namespace"].As(&namespace)
 		if namespace == "" {
 			namespace = "default"
 		}
-		res, err = rcl.Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		rs = rcl.Namespace(namespace)
+	}
+
+	var waitConfig tftypes.Value
+	if w, ok := dsConfig["wait"]; ok && !w.IsNull() {
+		var waitBlocks []tftypes.Value
+		w.As(&waitBlocks)
+		if len(waitBlocks) > 0 {
+			waitConfig = waitBlocks[0]
+		}
+	}
+
+	var res *unstructured.Unstructured
+	if waitConfig.IsNull() {
+		res, err = rs.Get(ctx, name, metav1.GetOptions{})
 	} else {
-		res, err = rcl.Get(ctx, name, metav1.GetOptions{})
+		timeout, terr := getDataSourceWaitTimeout(waitConfig)
+		if terr != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid wait timeout",
+				Detail:    terr.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("wait").WithElementKeyInt(0).WithAttributeName("timeout"),
+			})
+			return resp, nil
+		}
+		ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		res, err = s.waitForResource(ctxDeadline, waitConfig, rs, name, objectType, th, waiterSleepTime)
+		if reason, ok := err.(WaiterError); ok {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Operation timed out",
+				Detail:   reason.Error(),
+			})
+			return resp, nil
+		}
+		if reason, ok := err.(WaiterFailedError); ok {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Resource reached an error state while waiting",
+				Detail:    reason.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("wait").WithElementKeyInt(0).WithAttributeName("error_on"),
+			})
+			return resp, nil
+		}
 	}
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -409,6 +542,58 @@ func (s *RawProviderServer) ReadSingularDataSource(ctx context.Context, req *tfp
 	return resp, nil
 }
 
+// listPages lists the objects of a resource, following continue tokens to request 'pageSize' objects at a time,
+// until all objects, or 'limit' objects, have been listed. All objects are requested at once when 'pageSize' is zero,
+// and there is no limit when 'limit' is zero. The returned list holds the resource version of the first page,
+// which the following pages are consistent with, and the remaining item count of the last page.
+func listPages(ctx context.Context, rs lister, opts metav1.ListOptions, pageSize int64, limit int64) (*unstructured.UnstructuredList, error) {
+	out := &unstructured.UnstructuredList{}
+	for {
+		opts.Limit = pageSize
+		if limit > 0 {
+			left := limit - int64(len(out.Items))
+			if opts.Limit == 0 || opts.Limit > left {
+				opts.Limit = left
+			}
+		}
+		page, err := rs.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Continue == "" {
+			out.Object = page.Object
+			out.SetResourceVersion(page.GetResourceVersion())
+		}
+		out.Items = append(out.Items, page.Items...)
+		out.SetRemainingItemCount(page.GetRemainingItemCount())
+		out.SetContinue(page.GetContinue())
+
+		if page.GetContinue() == "" || (limit > 0 && int64(len(out.Items)) >= limit) {
+			return out, nil
+		}
+		opts.Continue = page.GetContinue()
+	}
+}
+
+// defaultDataSourceWaitTimeout is how long the kubernetes_resource data source waits when no 'timeout' is configured
+const defaultDataSourceWaitTimeout = 10 * time.Minute
+
+func getDataSourceWaitTimeout(waitConfig tftypes.Value) (time.Duration, error) {
+	var w map[string]tftypes.Value
+	if err := waitConfig.As(&w); err != nil {
+		return 0, err
+	}
+	t, ok := w["timeout"]
+	if !ok || t.IsNull() {
+		return defaultDataSourceWaitTimeout, nil
+	}
+	var timeout string
+	if err := t.As(&timeout); err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(timeout)
+}
+
 func getGVR(apiVersion, kind string, m meta.RESTMapper) (schema.GroupVersionResource, error) {
 	gv, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
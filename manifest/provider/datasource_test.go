@@ -0,0 +1,225 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// pagedResource serves a list of objects in pages, the way the API server does
+type pagedResource struct {
+	dynamic.ResourceInterface
+	names    []string
+	requests []metav1.ListOptions
+}
+
+func (r *pagedResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	r.requests = append(r.requests, opts)
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := len(r.names)
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetResourceVersion(fmt.Sprintf("%d", 100+len(r.requests)))
+	for _, n := range r.names[start:end] {
+		obj := unstructured.Unstructured{}
+		obj.SetName(n)
+		list.Items = append(list.Items, obj)
+	}
+	if end < len(r.names) {
+		list.SetContinue(strconv.Itoa(end))
+		remaining := int64(len(r.names) - end)
+		list.SetRemainingItemCount(&remaining)
+	}
+	return list, nil
+}
+
+func TestListPages(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	samples := map[string]struct {
+		pageSize  int64
+		limit     int64
+		limits    []int64
+		items     int
+		remaining *int64
+	}{
+		"all at once": {
+			limits: []int64{0},
+			items:  7,
+		},
+		"paged": {
+			pageSize: 3,
+			limits:   []int64{3, 3, 3},
+			items:    7,
+		},
+		"limited": {
+			limit:     4,
+			limits:    []int64{4},
+			items:     4,
+			remaining: ptrInt64(3),
+		},
+		"paged and limited": {
+			pageSize:  3,
+			limit:     5,
+			limits:    []int64{3, 2},
+			items:     5,
+			remaining: ptrInt64(2),
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			rs := &pagedResource{names: names}
+			res, err := listPages(context.Background(), rs, metav1.ListOptions{LabelSelector: "app=test"}, s.pageSize, s.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Items) != s.items {
+				t.Fatalf("expected %d items, got %d", s.items, len(res.Items))
+			}
+			for i, item := range res.Items {
+				if item.GetName() != names[i] {
+					t.Fatalf("unexpected item %d: %q", i, item.GetName())
+				}
+			}
+			if len(rs.requests) != len(s.limits) {
+				t.Fatalf("expected %d requests, got %d", len(s.limits), len(rs.requests))
+			}
+			for i, req := range rs.requests {
+				if req.Limit != s.limits[i] {
+					t.Fatalf("unexpected limit for request %d: %d", i, req.Limit)
+				}
+				if req.LabelSelector != "app=test" {
+					t.Fatalf("the label selector was not kept for request %d", i)
+				}
+			}
+			if res.GetResourceVersion() != "101" {
+				t.Fatalf("expected the resource version of the first page, got %q", res.GetResourceVersion())
+			}
+			rc := res.GetRemainingItemCount()
+			if (rc == nil) != (s.remaining == nil) || (rc != nil && *rc != *s.remaining) {
+				t.Fatalf("unexpected remaining item count: %v", rc)
+			}
+		})
+	}
+}
+
+func ptrInt64(i int64) *int64 {
+	return &i
+}
+
+// objectWithNulls builds a value of type t, with any attribute missing from vals set to null
+func objectWithNulls(t tftypes.Object, vals map[string]tftypes.Value) tftypes.Value {
+	attrs := make(map[string]tftypes.Value, len(t.AttributeTypes))
+	for k, at := range t.AttributeTypes {
+		if v, ok := vals[k]; ok {
+			attrs[k] = v
+			continue
+		}
+		attrs[k] = tftypes.NewValue(at, nil)
+	}
+	return tftypes.NewValue(t, attrs)
+}
+
+func TestValidateDataSourceConfig(t *testing.T) {
+	dt, err := GetDataSourceType("kubernetes_resource")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ot := dt.(tftypes.Object)
+	waitList := ot.AttributeTypes["wait"].(tftypes.List)
+	waitType := waitList.ElementType.(tftypes.Object)
+	errorOnList := waitType.AttributeTypes["error_on"].(tftypes.List)
+	errorOnType := errorOnList.ElementType.(tftypes.Object)
+	fieldsType := waitType.AttributeTypes["fields"]
+
+	fields := tftypes.NewValue(fieldsType, map[string]tftypes.Value{
+		"status.phase": tftypes.NewValue(tftypes.String, "Active"),
+	})
+	samples := map[string]struct {
+		wait    map[string]tftypes.Value
+		summary string
+	}{
+		"no waiter": {
+			wait: map[string]tftypes.Value{},
+		},
+		"single waiter": {
+			wait: map[string]tftypes.Value{
+				"fields":  fields,
+				"timeout": tftypes.NewValue(tftypes.String, "1m"),
+			},
+		},
+		"multiple waiters": {
+			wait: map[string]tftypes.Value{
+				"fields":     fields,
+				"expression": tftypes.NewValue(tftypes.String, "object.status.ready"),
+			},
+			summary: "Invalid wait configuration",
+		},
+		"invalid expression": {
+			wait: map[string]tftypes.Value{
+				"expression": tftypes.NewValue(tftypes.String, "object.status.ready =="),
+			},
+			summary: "Invalid wait expression",
+		},
+		"error_on without waiter": {
+			wait: map[string]tftypes.Value{
+				"error_on": tftypes.NewValue(errorOnList, []tftypes.Value{
+					objectWithNulls(errorOnType, map[string]tftypes.Value{"fields": fields}),
+				}),
+			},
+			summary: "Invalid wait configuration",
+		},
+		"invalid timeout": {
+			wait: map[string]tftypes.Value{
+				"fields":  fields,
+				"timeout": tftypes.NewValue(tftypes.String, "soon"),
+			},
+			summary: "Invalid wait timeout",
+		},
+	}
+	s := &RawProviderServer{logger: hclog.NewNullLogger()}
+	for name, sample := range samples {
+		t.Run(name, func(t *testing.T) {
+			config := objectWithNulls(ot, map[string]tftypes.Value{
+				"api_version": tftypes.NewValue(tftypes.String, "v1"),
+				"kind":        tftypes.NewValue(tftypes.String, "Namespace"),
+				"wait":        tftypes.NewValue(waitList, []tftypes.Value{objectWithNulls(waitType, sample.wait)}),
+			})
+			dv, err := tfprotov5.NewDynamicValue(ot, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := s.ValidateDataSourceConfig(context.Background(), &tfprotov5.ValidateDataSourceConfigRequest{
+				TypeName: "kubernetes_resource",
+				Config:   &dv,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sample.summary == "" {
+				if len(resp.Diagnostics) > 0 {
+					t.Fatalf("unexpected diagnostics: %s", resp.Diagnostics[0].Detail)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != sample.summary {
+				t.Fatalf("expected a single %q diagnostic, got %d", sample.summary, len(resp.Diagnostics))
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// getDeleteOptions returns the options used to delete a resource, as configured in its 'delete_options' block
+func getDeleteOptions(v map[string]tftypes.Value) (metav1.DeleteOptions, error) {
+	opts := metav1.DeleteOptions{}
+	do, ok := v["delete_options"]
+	if !ok || do.IsNull() || !do.IsKnown() {
+		return opts, nil
+	}
+	var blocks []tftypes.Value
+	if err := do.As(&blocks); err != nil {
+		return opts, err
+	}
+	if len(blocks) == 0 {
+		return opts, nil
+	}
+	var block map[string]tftypes.Value
+	if err := blocks[0].As(&block); err != nil {
+		return opts, err
+	}
+	if pp, ok := block["propagation_policy"]; ok && !pp.IsNull() {
+		if !pp.IsKnown() {
+			return opts, fmt.Errorf("the value of 'propagation_policy' must be known")
+		}
+		var policy string
+		if err := pp.As(&policy); err != nil {
+			return opts, err
+		}
+		dp := metav1.DeletionPropagation(policy)
+		switch dp {
+		case metav1.DeletePropagationForeground, metav1.DeletePropagationBackground, metav1.DeletePropagationOrphan:
+		default:
+			return opts, fmt.Errorf("unsupported propagation policy %q, must be one of %q, %q or %q", policy,
+				metav1.DeletePropagationForeground, metav1.DeletePropagationBackground, metav1.DeletePropagationOrphan)
+		}
+		opts.PropagationPolicy = &dp
+	}
+	if gp, ok := block["grace_period_seconds"]; ok && !gp.IsNull() {
+		if !gp.IsKnown() {
+			return opts, fmt.Errorf("the value of 'grace_period_seconds' must be known")
+		}
+		var f big.Float
+		if err := gp.As(&f); err != nil {
+			return opts, err
+		}
+		if !f.IsInt() || f.Sign() < 0 {
+			return opts, fmt.Errorf("'grace_period_seconds' must be a non-negative whole number, got %s", f.String())
+		}
+		seconds, _ := f.Int64()
+		opts.GracePeriodSeconds = &seconds
+	}
+	return opts, nil
+}
+
+// namespaceDeletionConditions are the conditions the namespace controller reports while a Namespace is being deleted
+var namespaceDeletionConditions = []string{
+	"NamespaceDeletionDiscoveryFailure",
+	"NamespaceDeletionGroupVersionParsingFailure",
+	"NamespaceDeletionContentFailure",
+	"NamespaceContentRemaining",
+	"NamespaceFinalizersRemaining",
+}
+
+// describeStuckDeletion explains what keeps a resource from being deleted:
+// its remaining finalizers and, for Namespaces, the conditions reported by the namespace controller.
+func describeStuckDeletion(obj *unstructured.Unstructured) string {
+	if obj == nil {
+		return ""
+	}
+	var details []string
+	if ts := obj.GetDeletionTimestamp(); ts != nil {
+		details = append(details, fmt.Sprintf("The resource was marked for deletion at %s.", ts.UTC().Format("2006-01-02T15:04:05Z")))
+	}
+	if finalizers := obj.GetFinalizers(); len(finalizers) > 0 {
+		details = append(details, fmt.Sprintf("Remaining finalizers: %s.", strings.Join(finalizers, ", ")))
+	}
+	if obj.GetKind() == "Namespace" && obj.GroupVersionKind().Group == "" {
+		if finalizers, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "finalizers"); len(finalizers) > 0 {
+			details = append(details, fmt.Sprintf("Remaining namespace finalizers: %s.", strings.Join(finalizers, ", ")))
+		}
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, ct := range namespaceDeletionConditions {
+			for _, c := range conditions {
+				cm, ok := c.(map[string]interface{})
+				if !ok || cm["type"] != ct || cm["status"] != string(metav1.ConditionTrue) {
+					continue
+				}
+				details = append(details, fmt.Sprintf("%s: %v", ct, cm["message"]))
+			}
+		}
+	}
+	return strings.Join(details, "\n")
+}
@@ -0,0 +1,126 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetDeleteOptions(t *testing.T) {
+	blockType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"propagation_policy":   tftypes.String,
+		"grace_period_seconds": tftypes.Number,
+	}}
+	deleteOptions := func(policy interface{}, grace interface{}) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"delete_options": tftypes.NewValue(tftypes.List{ElementType: blockType}, []tftypes.Value{
+				tftypes.NewValue(blockType, map[string]tftypes.Value{
+					"propagation_policy":   tftypes.NewValue(tftypes.String, policy),
+					"grace_period_seconds": tftypes.NewValue(tftypes.Number, grace),
+				}),
+			}),
+		}
+	}
+
+	opts, err := getDeleteOptions(map[string]tftypes.Value{
+		"delete_options": tftypes.NewValue(tftypes.List{ElementType: blockType}, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.PropagationPolicy != nil || opts.GracePeriodSeconds != nil {
+		t.Fatalf("expected default delete options, got: %#v", opts)
+	}
+
+	opts, err = getDeleteOptions(deleteOptions("Foreground", 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.PropagationPolicy == nil || *opts.PropagationPolicy != metav1.DeletePropagationForeground {
+		t.Fatalf("unexpected propagation policy: %v", opts.PropagationPolicy)
+	}
+	if opts.GracePeriodSeconds == nil || *opts.GracePeriodSeconds != 30 {
+		t.Fatalf("unexpected grace period: %v", opts.GracePeriodSeconds)
+	}
+
+	opts, err = getDeleteOptions(deleteOptions(nil, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.PropagationPolicy != nil || opts.GracePeriodSeconds == nil || *opts.GracePeriodSeconds != 0 {
+		t.Fatalf("unexpected delete options: %#v", opts)
+	}
+
+	for _, v := range []map[string]tftypes.Value{
+		deleteOptions("Cascade", nil),
+		deleteOptions(nil, -1),
+		deleteOptions(nil, 1.5),
+		deleteOptions(tftypes.UnknownValue, nil),
+	} {
+		if _, err := getDeleteOptions(v); err == nil {
+			t.Fatalf("expected error for %v", v)
+		}
+	}
+}
+
+func TestDescribeStuckDeletion(t *testing.T) {
+	if d := describeStuckDeletion(nil); d != "" {
+		t.Fatalf("unexpected description: %q", d)
+	}
+
+	ns := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+		"spec": map[string]interface{}{
+			"finalizers": []interface{}{"kubernetes"},
+		},
+		"status": map[string]interface{}{
+			"phase": "Terminating",
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":    "NamespaceDeletionDiscoveryFailure",
+					"status":  "False",
+					"message": "All resources successfully discovered",
+				},
+				map[string]interface{}{
+					"type":    "NamespaceContentRemaining",
+					"status":  "True",
+					"message": "Some resources are remaining: widgets.example.com has 1 resource instances",
+				},
+				map[string]interface{}{
+					"type":    "NamespaceFinalizersRemaining",
+					"status":  "True",
+					"message": "Some content in the namespace has finalizers remaining: example.com/cleanup in 1 resource instances",
+				},
+			},
+		},
+	}}
+	ns.SetDeletionTimestamp(&metav1.Time{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)})
+	ns.SetFinalizers([]string{"example.com/protect"})
+
+	d := describeStuckDeletion(ns)
+	for _, expected := range []string{
+		"marked for deletion at 2024-05-01T12:00:00Z",
+		"Remaining finalizers: example.com/protect.",
+		"Remaining namespace finalizers: kubernetes.",
+		"NamespaceContentRemaining: Some resources are remaining: widgets.example.com has 1 resource instances",
+		"NamespaceFinalizersRemaining: Some content in the namespace has finalizers remaining",
+	} {
+		if !strings.Contains(d, expected) {
+			t.Fatalf("expected description to contain %q, got:\n%s", expected, d)
+		}
+	}
+	if strings.Contains(d, "NamespaceDeletionDiscoveryFailure") {
+		t.Fatalf("unexpected condition in description:\n%s", d)
+	}
+}
@@ -4,9 +4,16 @@
 package provider
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,3 +42,201 @@ func APIStatusErrorToDiagnostics(s metav1.Status) []*tfprotov5.Diagnostic {
 	}
 	return diags
 }
+
+// applyErrorToDiagnostics describes an error returned by the API server for a server-side apply request.
+// Field manager conflicts are attached to the path of the conflicting fields of 'obj', the applied object,
+// under 'at', the attribute it was configured in. Either can be nil when unavailable.
+func applyErrorToDiagnostics(err error, rnn string, obj map[string]interface{}, at *tftypes.AttributePath) []*tfprotov5.Diagnostic {
+	if apierrors.IsConflict(err) {
+		if diags := conflictDiagnostics(err, rnn, obj, at); len(diags) > 0 {
+			return diags
+		}
+		return []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf(`There was a field manager conflict when trying to apply the manifest for %q`, rnn),
+				Detail: fmt.Sprintf(
+					"The API returned the following conflict: %q\n\n"+
+						"You can override this conflict by setting \"force_conflicts\" to true in the \"field_manager\" block.",
+					err.Error(),
+				),
+				Attribute: at,
+			},
+		}
+	}
+	if status := apierrors.APIStatus(nil); errors.As(err, &status) {
+		return APIStatusErrorToDiagnostics(status.Status())
+	}
+	return []*tfprotov5.Diagnostic{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Detail:   err.Error(),
+			Summary:  fmt.Sprintf(`PATCH for resource "%s" failed to apply`, rnn),
+		},
+	}
+}
+
+// conflictManagerRegexp matches the message of the causes of a field manager conflict,
+// such as: conflict with "kube-controller-manager" with subresource "scale" using apps/v1
+var conflictManagerRegexp = regexp.MustCompile(`^conflict with "([^"]*)"(?: with subresource "([^"]*)")?`)
+
+// conflictDiagnostics describes each of the fields of a server-side apply conflict,
+// along with the field manager that owns it.
+func conflictDiagnostics(err error, rnn string, obj map[string]interface{}, at *tftypes.AttributePath) []*tfprotov5.Diagnostic {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+	var diags []*tfprotov5.Diagnostic
+	for _, c := range status.Status().Details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		m := conflictManagerRegexp.FindStringSubmatch(c.Message)
+		if m == nil {
+			continue
+		}
+		manager := fmt.Sprintf("%q", m[1])
+		if m[2] != "" {
+			manager = fmt.Sprintf("%q (through the %s subresource)", m[1], m[2])
+		}
+		field := strings.TrimPrefix(c.Field, ".")
+		d := &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf(`There was a field manager conflict when trying to apply the manifest for %q`, rnn),
+			Detail: fmt.Sprintf(
+				"The field %q is managed by the field manager %s, which set it to a different value.\n\n"+
+					"To resolve the conflict, either remove the field from the manifest to leave it to %q, "+
+					"have %q stop managing the field, or set \"force_conflicts\" to true in the \"field_manager\" block "+
+					"to take ownership of the field.",
+				field, manager, m[1], m[1],
+			),
+		}
+		if at != nil {
+			d.Attribute = at
+			if obj != nil {
+				d.Attribute = fieldAttributePath(c.Field, obj, at)
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// fieldAttributePath returns the attribute path, under 'at', of the field of 'obj' at 'field', a path in the format
+// of server-side apply, such as .spec.containers[name="nginx"].image. The path stops at the last field found in 'obj'.
+func fieldAttributePath(field string, obj interface{}, at *tftypes.AttributePath) *tftypes.AttributePath {
+	for field != "" {
+		switch field[0] {
+		case '.':
+			m, ok := obj.(map[string]interface{})
+			if !ok {
+				return at
+			}
+			// map keys, such as label names, may contain dots themselves: use the longest key matching the path
+			rest := field[1:]
+			key := ""
+			found := false
+			for k := range m {
+				if !strings.HasPrefix(rest, k) || (len(rest) > len(k) && rest[len(k)] != '.' && rest[len(k)] != '[') {
+					continue
+				}
+				if !found || len(k) > len(key) {
+					key, found = k, true
+				}
+			}
+			if !found {
+				return at
+			}
+			at = at.WithAttributeName(key)
+			obj = m[key]
+			field = rest[len(key):]
+		case '[':
+			l, ok := obj.([]interface{})
+			if !ok {
+				return at
+			}
+			key, n, err := fieldSetListKey(field)
+			if err != nil {
+				return at
+			}
+			idx := -1
+			for i, e := range l {
+				if listItemMatches(key, i, e) {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				return at
+			}
+			at = at.WithElementKeyInt(idx)
+			obj = l[idx]
+			field = field[n:]
+		default:
+			return at
+		}
+	}
+	return at
+}
+
+// fieldSetListKey converts the list item selector at the start of a server-side apply path, such as [name="nginx"],
+// [="value"] or [0], to the key of the item in FieldsV1 field sets. It also returns the length of the selector.
+func fieldSetListKey(field string) (string, int, error) {
+	if !strings.HasPrefix(field, "[") {
+		return "", 0, fmt.Errorf("no list item selector in %q", field)
+	}
+	body := field[1:]
+	if end := strings.IndexByte(body, ']'); end > 0 {
+		if _, err := strconv.Atoi(body[:end]); err == nil {
+			return "i:" + body[:end], end + 2, nil
+		}
+	}
+	if strings.HasPrefix(body, "=") {
+		dec := json.NewDecoder(strings.NewReader(body[1:]))
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return "", 0, err
+		}
+		end := 1 + int(dec.InputOffset())
+		if end >= len(body) || body[end] != ']' {
+			return "", 0, fmt.Errorf("malformed list item selector in %q", field)
+		}
+		js, err := json.Marshal(v)
+		if err != nil {
+			return "", 0, err
+		}
+		return "v:" + string(js), end + 2, nil
+	}
+	key := map[string]interface{}{}
+	pos := 0
+	for {
+		eq := strings.IndexByte(body[pos:], '=')
+		if eq <= 0 {
+			return "", 0, fmt.Errorf("malformed list item selector in %q", field)
+		}
+		name := body[pos : pos+eq]
+		dec := json.NewDecoder(strings.NewReader(body[pos+eq+1:]))
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return "", 0, err
+		}
+		key[name] = v
+		pos += eq + 1 + int(dec.InputOffset())
+		if pos >= len(body) {
+			return "", 0, fmt.Errorf("malformed list item selector in %q", field)
+		}
+		if body[pos] == ']' {
+			break
+		}
+		if body[pos] != ',' {
+			return "", 0, fmt.Errorf("malformed list item selector in %q", field)
+		}
+		pos++
+	}
+	js, err := json.Marshal(key)
+	if err != nil {
+		return "", 0, err
+	}
+	return "k:" + string(js), pos + 2, nil
+}
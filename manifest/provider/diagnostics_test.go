@@ -0,0 +1,152 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFieldSetListKey(t *testing.T) {
+	samples := map[string]struct {
+		key string
+		n   int
+	}{
+		`[name="nginx"].image`:              {`k:{"name":"nginx"}`, 14},
+		`[containerPort=80,protocol="TCP"]`: {`k:{"containerPort":80,"protocol":"TCP"}`, 33},
+		`[name="a]b"]`:                      {`k:{"name":"a]b"}`, 12},
+		`[="example.com/cleanup"]`:          {`v:"example.com/cleanup"`, 24},
+		`[2].name`:                          {`i:2`, 3},
+	}
+	for in, s := range samples {
+		t.Run(in, func(t *testing.T) {
+			key, n, err := fieldSetListKey(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key != s.key || n != s.n {
+				t.Fatalf("expected %s (%d), got %s (%d)", s.key, s.n, key, n)
+			}
+		})
+	}
+	for _, in := range []string{`name="nginx"]`, `[name="nginx"`, `[name]`} {
+		if _, _, err := fieldSetListKey(in); err == nil {
+			t.Fatalf("expected error for %s", in)
+		}
+	}
+}
+
+func TestFieldAttributePath(t *testing.T) {
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"app":                    "web",
+				"app.kubernetes.io/name": "web",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "sidecar", "image": "envoy"},
+						map[string]interface{}{
+							"name":  "nginx",
+							"image": "nginx",
+							"ports": []interface{}{
+								map[string]interface{}{"containerPort": int64(80), "protocol": "TCP"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	at := tftypes.NewAttributePath().WithAttributeName("manifest")
+	samples := map[string]*tftypes.AttributePath{
+		".spec.replicas": at.WithAttributeName("spec").WithAttributeName("replicas"),
+		".metadata.labels.app.kubernetes.io/name": at.WithAttributeName("metadata").WithAttributeName("labels").
+			WithAttributeName("app.kubernetes.io/name"),
+		`.spec.template.spec.containers[name="nginx"].image`: at.WithAttributeName("spec").WithAttributeName("template").
+			WithAttributeName("spec").WithAttributeName("containers").WithElementKeyInt(1).WithAttributeName("image"),
+		`.spec.template.spec.containers[name="nginx"].ports[containerPort=80,protocol="TCP"].containerPort`: at.WithAttributeName("spec").
+			WithAttributeName("template").WithAttributeName("spec").WithAttributeName("containers").WithElementKeyInt(1).
+			WithAttributeName("ports").WithElementKeyInt(0).WithAttributeName("containerPort"),
+		// paths stop at the last field found in the object
+		`.spec.template.spec.containers[name="missing"].image`: at.WithAttributeName("spec").WithAttributeName("template").
+			WithAttributeName("spec").WithAttributeName("containers"),
+		".spec.paused": at.WithAttributeName("spec"),
+	}
+	for field, expected := range samples {
+		t.Run(field, func(t *testing.T) {
+			if p := fieldAttributePath(field, obj, at); !p.Equal(expected) {
+				t.Fatalf("expected %s, got %s", expected, p)
+			}
+		})
+	}
+}
+
+func TestApplyConflictDiagnostics(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "nginx", "image": "nginx:1.27"},
+					},
+				},
+			},
+		},
+	}
+	err := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager" with subresource "scale" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-client-side-apply" using apps/v1`,
+			Field:   `.spec.template.spec.containers[name="nginx"].image`,
+		},
+	}, "Apply failed with 2 conflicts")
+	at := tftypes.NewAttributePath().WithAttributeName("manifest")
+
+	diags := applyErrorToDiagnostics(err, "default/test", obj, at)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+	if !strings.Contains(diags[0].Detail, `"spec.replicas" is managed by the field manager "kube-controller-manager" (through the scale subresource)`) {
+		t.Fatalf("unexpected diagnostic: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	if p := at.WithAttributeName("spec").WithAttributeName("replicas"); !diags[0].Attribute.Equal(p) {
+		t.Fatalf("expected attribute %s, got %s", p, diags[0].Attribute)
+	}
+	if !strings.Contains(diags[1].Detail, `"spec.template.spec.containers[name=\"nginx\"].image" is managed by the field manager "kubectl-client-side-apply"`) {
+		t.Fatalf("unexpected diagnostic: %s", diags[1].Detail)
+	}
+	p := at.WithAttributeName("spec").WithAttributeName("template").WithAttributeName("spec").
+		WithAttributeName("containers").WithElementKeyInt(0).WithAttributeName("image")
+	if !diags[1].Attribute.Equal(p) {
+		t.Fatalf("expected attribute %s, got %s", p, diags[1].Attribute)
+	}
+
+	// without the applied object, the diagnostics are attached to the attribute as a whole
+	for _, d := range applyErrorToDiagnostics(err, "default/test", nil, at) {
+		if !d.Attribute.Equal(at) {
+			t.Fatalf("expected attribute %s, got %s", at, d.Attribute)
+		}
+	}
+
+	// conflicts without causes fall back to the message of the API server
+	diags = applyErrorToDiagnostics(apierrors.NewApplyConflict(nil, "Apply failed"), "default/test", obj, nil)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "force_conflicts") {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// builtinImmutableFields lists the fields of built-in kinds that the API server refuses to change once the resource is created
+var builtinImmutableFields = map[schema.GroupKind][]string{
+	{Group: "", Kind: "PersistentVolumeClaim"}: {
+		"spec.accessModes",
+		"spec.dataSource",
+		"spec.dataSourceRef",
+		"spec.selector",
+		"spec.storageClassName",
+		"spec.volumeMode",
+		"spec.volumeName",
+	},
+	{Group: "", Kind: "Secret"}:                                      {"type"},
+	{Group: "", Kind: "Service"}:                                     {"spec.clusterIP"},
+	{Group: "apps", Kind: "DaemonSet"}:                               {"spec.selector"},
+	{Group: "apps", Kind: "Deployment"}:                              {"spec.selector"},
+	{Group: "apps", Kind: "ReplicaSet"}:                              {"spec.selector"},
+	{Group: "apps", Kind: "StatefulSet"}:                             {"spec.podManagementPolicy", "spec.selector", "spec.serviceName", "spec.volumeClaimTemplates"},
+	{Group: "batch", Kind: "Job"}:                                    {"spec.completionMode", "spec.selector"},
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:               {"spec.controller"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}: {"roleRef"},
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}:        {"roleRef"},
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                  {"parameters", "provisioner", "reclaimPolicy", "volumeBindingMode"},
+}
+
+// fieldPath converts a dot separated field path, like the ones reported by the API server in validation errors,
+// to an attribute path. Map keys and list indices, reported in brackets, are left out so the path designates
+// the attribute holding them.
+func fieldPath(f string) *tftypes.AttributePath {
+	if i := strings.Index(f, "["); i >= 0 {
+		f = f[:i]
+	}
+	p := tftypes.NewAttributePath()
+	for _, n := range strings.Split(f, ".") {
+		if n == "" {
+			continue
+		}
+		p = p.WithAttributeName(n)
+	}
+	return p
+}
+
+// builtinImmutableFieldPaths returns the paths of the immutable fields of a built-in kind
+func builtinImmutableFieldPaths(gvk schema.GroupVersionKind) []*tftypes.AttributePath {
+	var paths []*tftypes.AttributePath
+	for _, f := range builtinImmutableFields[gvk.GroupKind()] {
+		paths = append(paths, fieldPath(f))
+	}
+	return paths
+}
+
+// isImmutabilityRule reports whether a CEL validation rule is a transition rule keeping a value unchanged
+func isImmutabilityRule(rule string) bool {
+	r := strings.Join(strings.Fields(rule), "")
+	return r == "self==oldSelf" || r == "oldSelf==self"
+}
+
+// crdImmutableFieldPaths returns the paths of the fields of a CRD schema made immutable
+// with a 'self == oldSelf' rule in their 'x-kubernetes-validations'.
+// The elements of lists and maps are not inspected, as their paths are not known ahead of time.
+func crdImmutableFieldPaths(sch interface{}, p *tftypes.AttributePath) []*tftypes.AttributePath {
+	s, ok := sch.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var paths []*tftypes.AttributePath
+	if len(p.Steps()) > 0 {
+		vs, _ := s["x-kubernetes-validations"].([]interface{})
+		for _, v := range vs {
+			vm, _ := v.(map[string]interface{})
+			if rule, ok := vm["rule"].(string); ok && isImmutabilityRule(rule) {
+				paths = append(paths, p)
+				break
+			}
+		}
+	}
+	props, _ := s["properties"].(map[string]interface{})
+	for name, ps := range props {
+		paths = append(paths, crdImmutableFieldPaths(ps, p.WithAttributeName(name))...)
+	}
+	return paths
+}
+
+// immutableFieldCauses returns the paths of the fields a request was rejected for attempting to change them
+func immutableFieldCauses(err error) []*tftypes.AttributePath {
+	var se apierrors.APIStatus
+	if !apierrors.IsInvalid(err) || !errors.As(err, &se) {
+		return nil
+	}
+	details := se.Status().Details
+	if details == nil {
+		return nil
+	}
+	var paths []*tftypes.AttributePath
+	for _, c := range details.Causes {
+		if c.Field == "" || !strings.Contains(c.Message, "immutable") {
+			continue
+		}
+		paths = append(paths, fieldPath(c.Field))
+	}
+	return paths
+}
+
+// changedFields returns the paths, in the 'object' attribute, of the fields which hold different values
+// in the prior and planned objects. Fields with unknown values are ignored, as whether they change
+// is only known after apply.
+func changedFields(prior, planned tftypes.Value, paths []*tftypes.AttributePath) []*tftypes.AttributePath {
+	var changed []*tftypes.AttributePath
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if seen[p.String()] {
+			continue
+		}
+		seen[p.String()] = true
+		pv, _, err := tftypes.WalkAttributePath(prior, p)
+		if err != nil {
+			continue
+		}
+		nv, _, err := tftypes.WalkAttributePath(planned, p)
+		if err != nil {
+			continue
+		}
+		if !pv.(tftypes.Value).IsFullyKnown() || !nv.(tftypes.Value).IsFullyKnown() || pv.(tftypes.Value).Equal(nv.(tftypes.Value)) {
+			continue
+		}
+		steps := append([]tftypes.AttributePathStep{tftypes.AttributeName("object")}, p.Steps()...)
+		changed = append(changed, tftypes.NewAttributePathWithSteps(steps))
+	}
+	return changed
+}
+
+// clusterImmutableFieldPaths returns the paths of the fields of a resource which the API server refuses to change in-place,
+// from the transition rules of its CRD schema and, when 'dryRun' is set, from the fields a server-side dry-run
+// of the manifest is rejected for.
+func (s *RawProviderServer) clusterImmutableFieldPaths(ctx context.Context, gvk schema.GroupVersionKind, manifest tftypes.Value, th map[string]string, fieldManager string, isNamespaced bool, dryRun bool) []*tftypes.AttributePath {
+	crd, err := s.lookUpGVKinCRDs(ctx, gvk)
+	if err != nil {
+		s.logger.Debug("[clusterImmutableFieldPaths]", "failed to look up CRD schema", err.Error())
+	}
+	paths := crdImmutableFieldPaths(crd, tftypes.NewAttributePath())
+	if !dryRun {
+		return paths
+	}
+	// conflicts with other field managers are forced, so that the dry-run goes on to validate the changes
+	_, err = s.dryRun(ctx, manifest, th, fieldManager, true, isNamespaced)
+	if err != nil {
+		s.logger.Debug("[clusterImmutableFieldPaths]", "dry-run failed", err.Error())
+	}
+	return append(paths, immutableFieldCauses(err)...)
+}
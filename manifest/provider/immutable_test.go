@@ -0,0 +1,156 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func pathStrings(paths []*tftypes.AttributePath) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = p.String()
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestFieldPath(t *testing.T) {
+	samples := map[string]string{
+		"spec.selector":                          `AttributeName("spec").AttributeName("selector")`,
+		"spec.template.spec.containers[0].image": `AttributeName("spec").AttributeName("template").AttributeName("spec").AttributeName("containers")`,
+		"data[key]":                              `AttributeName("data")`,
+	}
+	for in, out := range samples {
+		t.Run(in, func(t *testing.T) {
+			if p := fieldPath(in).String(); p != out {
+				t.Fatalf("expected %s, got %s", out, p)
+			}
+		})
+	}
+}
+
+func TestBuiltinImmutableFieldPaths(t *testing.T) {
+	paths := builtinImmutableFieldPaths(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	if fmt.Sprint(pathStrings(paths)) != fmt.Sprint([]string{`AttributeName("spec").AttributeName("selector")`}) {
+		t.Fatalf("unexpected paths: %v", pathStrings(paths))
+	}
+	paths = builtinImmutableFieldPaths(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+	if len(paths) != 0 {
+		t.Fatalf("unexpected paths: %v", pathStrings(paths))
+	}
+}
+
+func TestCrdImmutableFieldPaths(t *testing.T) {
+	sch := map[string]interface{}{
+		"type": "object",
+		"x-kubernetes-validations": []interface{}{
+			map[string]interface{}{"rule": "self == oldSelf"},
+		},
+		"properties": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"region": map[string]interface{}{
+						"type": "string",
+						"x-kubernetes-validations": []interface{}{
+							map[string]interface{}{"rule": "self==oldSelf", "message": "region is immutable"},
+						},
+					},
+					"size": map[string]interface{}{
+						"type": "integer",
+						"x-kubernetes-validations": []interface{}{
+							map[string]interface{}{"rule": "self >= oldSelf"},
+						},
+					},
+					"network": map[string]interface{}{
+						"type": "object",
+						"x-kubernetes-validations": []interface{}{
+							map[string]interface{}{"rule": "oldSelf == self"},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := []string{
+		`AttributeName("spec").AttributeName("network")`,
+		`AttributeName("spec").AttributeName("region")`,
+	}
+	paths := crdImmutableFieldPaths(sch, tftypes.NewAttributePath())
+	if fmt.Sprint(pathStrings(paths)) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, pathStrings(paths))
+	}
+	if paths := crdImmutableFieldPaths(nil, tftypes.NewAttributePath()); len(paths) != 0 {
+		t.Fatalf("unexpected paths for missing schema: %v", pathStrings(paths))
+	}
+}
+
+func TestImmutableFieldCauses(t *testing.T) {
+	gk := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	err := apierrors.NewInvalid(gk, "test", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+		field.Required(field.NewPath("spec", "template", "metadata", "labels"), ""),
+	})
+	expected := []string{`AttributeName("spec").AttributeName("selector")`}
+	if paths := immutableFieldCauses(err); fmt.Sprint(pathStrings(paths)) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, pathStrings(paths))
+	}
+	if paths := immutableFieldCauses(apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "test", fmt.Errorf("conflict"))); len(paths) != 0 {
+		t.Fatalf("unexpected paths: %v", pathStrings(paths))
+	}
+	if paths := immutableFieldCauses(nil); len(paths) != 0 {
+		t.Fatalf("unexpected paths: %v", pathStrings(paths))
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	selectorType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"matchLabels": tftypes.Map{ElementType: tftypes.String},
+	}}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"spec": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"selector": selectorType,
+			"replicas": tftypes.Number,
+			"paused":   tftypes.Bool,
+		}},
+	}}
+	obj := func(app string, replicas interface{}, paused interface{}) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"spec": tftypes.NewValue(objType.AttributeTypes["spec"], map[string]tftypes.Value{
+				"selector": tftypes.NewValue(selectorType, map[string]tftypes.Value{
+					"matchLabels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+						"app": tftypes.NewValue(tftypes.String, app),
+					}),
+				}),
+				"replicas": tftypes.NewValue(tftypes.Number, replicas),
+				"paused":   tftypes.NewValue(tftypes.Bool, paused),
+			}),
+		})
+	}
+	paths := []*tftypes.AttributePath{
+		fieldPath("spec.selector"),
+		fieldPath("spec.selector"),
+		fieldPath("spec.replicas"),
+		fieldPath("spec.paused"),
+		fieldPath("spec.missing"),
+	}
+	prior := obj("foo", 1, false)
+	planned := obj("bar", tftypes.UnknownValue, false)
+
+	expected := []string{`AttributeName("object").AttributeName("spec").AttributeName("selector")`}
+	if changed := changedFields(prior, planned, paths); fmt.Sprint(pathStrings(changed)) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, pathStrings(changed))
+	}
+	if changed := changedFields(prior, prior, paths); len(changed) != 0 {
+		t.Fatalf("unexpected changes: %v", pathStrings(changed))
+	}
+}
@@ -26,6 +26,15 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 	// Presumably the Kubernetes API machinery already has a standard for expressing such a group. We should look there first.
 	resp := &tfprotov5.ImportResourceStateResponse{}
 
+	if req.TypeName != "kubernetes_manifest" {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Import not supported",
+			Detail:   fmt.Sprintf("The %s resource does not support import.", req.TypeName),
+		})
+		return resp, nil
+	}
+
 	cp := req.ClientCapabilities
 	if cp != nil && cp.DeferralAllowed && s.clientConfigUnknown {
 		v := tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue)
@@ -173,15 +182,21 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 	wtype := rt.(tftypes.Object).AttributeTypes["wait"]
 	timeoutsType := rt.(tftypes.Object).AttributeTypes["timeouts"]
 	fmType := rt.(tftypes.Object).AttributeTypes["field_manager"]
+	doType := rt.(tftypes.Object).AttributeTypes["delete_options"]
 	cmpType := rt.(tftypes.Object).AttributeTypes["computed_fields"]
 
 	newState["manifest"] = tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, nil)
+	newState["manifest_yaml"] = tftypes.NewValue(tftypes.String, nil)
 	newState["object"] = morph.UnknownToNull(nobj)
 	newState["wait_for"] = tftypes.NewValue(wftype, nil)
 	newState["wait"] = tftypes.NewValue(wtype, nil)
 	newState["timeouts"] = tftypes.NewValue(timeoutsType, nil)
 	newState["field_manager"] = tftypes.NewValue(fmType, nil)
+	newState["delete_options"] = tftypes.NewValue(doType, nil)
 	newState["computed_fields"] = tftypes.NewValue(cmpType, nil)
+	newState["owned_fields_only"] = tftypes.NewValue(tftypes.Bool, nil)
+	newState["plan_with_dry_run"] = tftypes.NewValue(tftypes.Bool, nil)
+	newState["subresource"] = tftypes.NewValue(tftypes.String, nil)
 
 	nsVal := tftypes.NewValue(rt, newState)
 
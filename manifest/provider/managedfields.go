@@ -0,0 +1,483 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/csaupgrade"
+	"k8s.io/client-go/util/retry"
+)
+
+// isManagerApplyEntry reports whether a managedFields entry records the fields applied by a field manager
+// to the resource itself, rather than to one of its subresources
+func isManagerApplyEntry(e metav1.ManagedFieldsEntry, manager string) bool {
+	return e.Manager == manager && e.Operation == metav1.ManagedFieldsOperationApply && e.Subresource == ""
+}
+
+// managerFieldSet returns the set of fields applied by a field manager, in the format of the FieldsV1 managedFields entries.
+// The set is empty when the field manager doesn't own any fields.
+func managerFieldSet(managedFields []metav1.ManagedFieldsEntry, manager string) (map[string]interface{}, error) {
+	set := map[string]interface{}{}
+	for _, e := range managedFields {
+		if !isManagerApplyEntry(e, manager) || e.FieldsV1 == nil {
+			continue
+		}
+		var fs map[string]interface{}
+		if err := json.Unmarshal(e.FieldsV1.Raw, &fs); err != nil {
+			return nil, err
+		}
+		mergeFieldSets(set, fs)
+	}
+	return set, nil
+}
+
+func mergeFieldSets(dst, src map[string]interface{}) {
+	for k, v := range src {
+		sv, _ := v.(map[string]interface{})
+		dv, ok := dst[k].(map[string]interface{})
+		if !ok {
+			dst[k] = sv
+			continue
+		}
+		mergeFieldSets(dv, sv)
+	}
+}
+
+// ownedFields returns the parts of an object owned by a field manager through server-side apply,
+// along with the apiVersion, kind, name and namespace identifying the object.
+func ownedFields(obj *unstructured.Unstructured, manager string) (map[string]interface{}, error) {
+	set, err := managerFieldSet(obj.GetManagedFields(), manager)
+	if err != nil {
+		return nil, err
+	}
+	out, _ := filterFieldSet(obj.Object, set).(map[string]interface{})
+	if out == nil {
+		out = map[string]interface{}{}
+	}
+	out["apiVersion"] = obj.GetAPIVersion()
+	out["kind"] = obj.GetKind()
+	md, _ := out["metadata"].(map[string]interface{})
+	if md == nil {
+		md = map[string]interface{}{}
+	}
+	md["name"] = obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		md["namespace"] = ns
+	}
+	out["metadata"] = md
+	return out, nil
+}
+
+// filterFieldSet returns the parts of a value that are members of a FieldsV1 field set, or nil if there are none.
+// Field sets are trees with keys of the form "f:<field>", "k:<list item key>", "v:<list item value>" and "i:<list index>".
+func filterFieldSet(v interface{}, set map[string]interface{}) interface{} {
+	_, self := set["."]
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, sub := range set {
+			if !strings.HasPrefix(k, "f:") {
+				continue
+			}
+			name := strings.TrimPrefix(k, "f:")
+			child, ok := tv[name]
+			if !ok {
+				continue
+			}
+			if fc := filterMember(child, sub); fc != nil {
+				out[name] = fc
+			}
+		}
+		if len(out) == 0 && !self {
+			return nil
+		}
+		return out
+	case []interface{}:
+		out := []interface{}{}
+		for i, e := range tv {
+			for k, sub := range set {
+				if !listItemMatches(k, i, e) {
+					continue
+				}
+				if fe := filterMember(e, sub); fe != nil {
+					out = append(out, fe)
+				}
+				break
+			}
+		}
+		if len(out) == 0 && !self {
+			return nil
+		}
+		return out
+	}
+	return nil
+}
+
+// filterMember returns the parts of a value that are members of the field set 'sub' it is described by.
+// Empty field sets describe values that are owned in their entirety.
+func filterMember(v interface{}, sub interface{}) interface{} {
+	ss, _ := sub.(map[string]interface{})
+	if len(ss) == 0 {
+		return v
+	}
+	return filterFieldSet(v, ss)
+}
+
+// listItemMatches reports whether the element 'e' at index 'i' of a list is the list item designated by field set key 'k'
+func listItemMatches(k string, i int, e interface{}) bool {
+	switch {
+	case strings.HasPrefix(k, "k:"):
+		var key map[string]interface{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(k, "k:")), &key); err != nil {
+			return false
+		}
+		em, ok := e.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for kk, kv := range key {
+			if !jsonEqual(em[kk], kv) {
+				return false
+			}
+		}
+		return true
+	case strings.HasPrefix(k, "v:"):
+		var value interface{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(k, "v:")), &value); err != nil {
+			return false
+		}
+		return jsonEqual(e, value)
+	case strings.HasPrefix(k, "i:"):
+		idx, err := strconv.Atoi(strings.TrimPrefix(k, "i:"))
+		return err == nil && idx == i
+	}
+	return false
+}
+
+// jsonEqual compares values by their JSON encoding, so that numbers of different Go types are equal
+func jsonEqual(a, b interface{}) bool {
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ja) == string(jb)
+}
+
+// releaseManagedFields returns the managedFields of an object without the entries of a field manager,
+// and whether the field manager had any entries to release.
+func releaseManagedFields(managedFields []metav1.ManagedFieldsEntry, manager string) ([]metav1.ManagedFieldsEntry, bool) {
+	var remaining []metav1.ManagedFieldsEntry
+	for _, e := range managedFields {
+		if isManagerApplyEntry(e, manager) {
+			continue
+		}
+		remaining = append(remaining, e)
+	}
+	return remaining, len(remaining) != len(managedFields)
+}
+
+// migrateManagedFields transfers the ownership of the fields set through client-side apply or updates by the field managers
+// 'from' to the server-side apply field manager 'to', the way kubectl does when moving to server-side apply.
+// Only the fields present in the manifest 'manifest' are transferred: the other fields stay with the field managers
+// they belonged to, as the next apply would otherwise remove them from the object.
+// It reports whether there were any fields to migrate.
+func migrateManagedFields(ctx context.Context, rs dynamic.ResourceInterface, name string, from []string, to string, subresource string, manifest map[string]interface{}) (bool, error) {
+	var opts []csaupgrade.Option
+	if subresource != "" {
+		opts = append(opts, csaupgrade.Subresource(subresource))
+	}
+	migrated := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := rs.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		managedFields, ok, err := migratedManagedFields(obj, from, to, subresource, manifest, opts...)
+		if err != nil || !ok {
+			return err
+		}
+		// the patch also replaces the resourceVersion, so that it fails on conflict if the object changed in the meantime
+		patch, err := json.Marshal([]map[string]interface{}{
+			{"op": "replace", "path": "/metadata/managedFields", "value": managedFields},
+			{"op": "replace", "path": "/metadata/resourceVersion", "value": obj.GetResourceVersion()},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := rs.Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{}); err != nil {
+			return err
+		}
+		migrated = true
+		return nil
+	})
+	return migrated, err
+}
+
+// migratedManagedFields returns the managedFields of an object once the fields of the field managers 'from'
+// that are present in the manifest are transferred to the field manager 'to', and whether any fields were transferred.
+func migratedManagedFields(obj *unstructured.Unstructured, from []string, to string, subresource string, manifest map[string]interface{}, opts ...csaupgrade.Option) ([]metav1.ManagedFieldsEntry, bool, error) {
+	managedFields := obj.GetManagedFields()
+	upgraded := obj.DeepCopy()
+	if err := csaupgrade.UpgradeManagedFields(upgraded, sets.New(from...), to, opts...); err != nil {
+		return nil, false, err
+	}
+	entries := upgraded.GetManagedFields()
+	idx := -1
+	for i, e := range entries {
+		if e.Manager == to && e.Operation == metav1.ManagedFieldsOperationApply && e.Subresource == subresource {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, false, nil
+	}
+
+	prior := map[string]interface{}{}
+	for _, e := range managedFields {
+		if e.Manager == to && e.Operation == metav1.ManagedFieldsOperationApply && e.Subresource == subresource {
+			if err := decodeFieldSet(e, prior); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	set := map[string]interface{}{}
+	if err := decodeFieldSet(entries[idx], set); err != nil {
+		return nil, false, err
+	}
+	inManifest := intersectFieldSet(set, manifest)
+	owned := map[string]interface{}{}
+	mergeFieldSets(owned, prior)
+	mergeFieldSets(owned, inManifest)
+	if reflect.DeepEqual(owned, prior) {
+		return nil, false, nil
+	}
+	raw, err := json.Marshal(owned)
+	if err != nil {
+		return nil, false, err
+	}
+	entries[idx].FieldsV1 = &metav1.FieldsV1{Raw: raw}
+
+	// the fields not present in the manifest stay with the field managers they were migrated from
+	for _, e := range managedFields {
+		if !sets.New(from...).Has(e.Manager) || e.Subresource != subresource || hasManagedFieldsEntry(entries, e) {
+			continue
+		}
+		fs := map[string]interface{}{}
+		if err := decodeFieldSet(e, fs); err != nil {
+			return nil, false, err
+		}
+		rest := subtractFieldSet(fs, inManifest)
+		if len(rest) == 0 {
+			continue
+		}
+		raw, err := json.Marshal(rest)
+		if err != nil {
+			return nil, false, err
+		}
+		e.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		entries = append(entries, e)
+	}
+	return entries, true, nil
+}
+
+// decodeFieldSet merges the set of fields of a managedFields entry into 'set'
+func decodeFieldSet(e metav1.ManagedFieldsEntry, set map[string]interface{}) error {
+	if e.FieldsV1 == nil {
+		return nil
+	}
+	var fs map[string]interface{}
+	if err := json.Unmarshal(e.FieldsV1.Raw, &fs); err != nil {
+		return err
+	}
+	mergeFieldSets(set, fs)
+	return nil
+}
+
+// hasManagedFieldsEntry reports whether managedFields holds an entry for the same field manager, operation and subresource as 'e'
+func hasManagedFieldsEntry(managedFields []metav1.ManagedFieldsEntry, e metav1.ManagedFieldsEntry) bool {
+	for _, m := range managedFields {
+		if m.Manager == e.Manager && m.Operation == e.Operation && m.Subresource == e.Subresource {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectFieldSet returns the members of a FieldsV1 field set that are present in value 'v'
+func intersectFieldSet(set map[string]interface{}, v interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, sub := range set {
+		if k == "." {
+			out[k] = sub
+			continue
+		}
+		var child interface{}
+		found := false
+		switch tv := v.(type) {
+		case map[string]interface{}:
+			if strings.HasPrefix(k, "f:") {
+				child, found = tv[strings.TrimPrefix(k, "f:")]
+			}
+		case []interface{}:
+			for i, e := range tv {
+				if listItemMatches(k, i, e) {
+					child, found = e, true
+					break
+				}
+			}
+		}
+		if !found {
+			continue
+		}
+		ss, _ := sub.(map[string]interface{})
+		if len(ss) == 0 {
+			out[k] = map[string]interface{}{}
+			continue
+		}
+		if is := intersectFieldSet(ss, child); len(is) > 0 {
+			out[k] = is
+		}
+	}
+	return out
+}
+
+// subtractFieldSet returns the members of a FieldsV1 field set that are not members of the field set 'remove'
+func subtractFieldSet(set, remove map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, sub := range set {
+		rsub, ok := remove[k]
+		if !ok {
+			out[k] = sub
+			continue
+		}
+		ss, _ := sub.(map[string]interface{})
+		rs, _ := rsub.(map[string]interface{})
+		if len(ss) == 0 || len(rs) == 0 {
+			continue
+		}
+		if d := subtractFieldSet(ss, rs); len(d) > 0 {
+			out[k] = d
+		}
+	}
+	return out
+}
+
+// fieldSetCovers reports whether all the fields of value 'v' are members of a FieldsV1 field set
+func fieldSetCovers(set map[string]interface{}, v interface{}) bool {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for k, cv := range tv {
+			if cv == nil {
+				// null values are dropped by the API server
+				continue
+			}
+			sub, ok := set["f:"+k]
+			if !ok {
+				return false
+			}
+			if ss, _ := sub.(map[string]interface{}); len(ss) > 0 && !fieldSetCovers(ss, cv) {
+				return false
+			}
+		}
+	case []interface{}:
+		for i, e := range tv {
+			_, sub, ok := ownedListItem(set, i, e)
+			if !ok {
+				return false
+			}
+			if ss, _ := sub.(map[string]interface{}); len(ss) > 0 && !fieldSetCovers(ss, e) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ownedFieldsOnly returns the 'live' value of an object, except for the fields configured in its manifest 'cfg'
+// that are not owned by a field manager according to its field set 'set': those keep their 'prior' value,
+// so that changes made to them by other field managers are not reported as drift.
+// The elements of lists not owned by the field manager, such as injected sidecar containers, are left out.
+func ownedFieldsOnly(live, prior, cfg interface{}, set map[string]interface{}) interface{} {
+	switch l := live.(type) {
+	case map[string]interface{}:
+		c, ok := cfg.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		p, _ := prior.(map[string]interface{})
+		out := make(map[string]interface{}, len(l))
+		for k, v := range l {
+			out[k] = v
+		}
+		for k, cv := range c {
+			sub, owned := set["f:"+k]
+			if !owned {
+				if pv, ok := p[k]; ok {
+					out[k] = pv
+				}
+				continue
+			}
+			ss, _ := sub.(map[string]interface{})
+			if lv, ok := l[k]; ok && len(ss) > 0 {
+				out[k] = ownedFieldsOnly(lv, p[k], cv, ss)
+			}
+		}
+		return out
+	case []interface{}:
+		c, _ := cfg.([]interface{})
+		p, _ := prior.([]interface{})
+		out := make([]interface{}, 0, len(l))
+		for i, e := range l {
+			key, sub, owned := ownedListItem(set, i, e)
+			if !owned {
+				continue
+			}
+			ss, _ := sub.(map[string]interface{})
+			ce, inCfg := findListItem(c, key)
+			if !inCfg || len(ss) == 0 {
+				out = append(out, e)
+				continue
+			}
+			pe, _ := findListItem(p, key)
+			out = append(out, ownedFieldsOnly(e, pe, ce, ss))
+		}
+		return out
+	}
+	return live
+}
+
+// ownedListItem returns the key and field set of the element 'e' at index 'i' of a list, if it is a member of the field set
+func ownedListItem(set map[string]interface{}, i int, e interface{}) (string, interface{}, bool) {
+	for k, sub := range set {
+		if listItemMatches(k, i, e) {
+			return k, sub, true
+		}
+	}
+	return "", nil, false
+}
+
+// findListItem returns the element of a list designated by field set key 'k'
+func findListItem(l []interface{}, k string) (interface{}, bool) {
+	for i, e := range l {
+		if listItemMatches(k, i, e) {
+			return e, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,338 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func newManagedFieldsEntry(manager string, op metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  op,
+		APIVersion: "apps/v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestOwnedFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+			"labels": map[string]interface{}{
+				"app":  "test",
+				"team": "platform",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "app",
+							"image": "app:2",
+						},
+						map[string]interface{}{
+							"name":  "sidecar",
+							"image": "sidecar:1",
+						},
+					},
+				},
+			},
+		},
+	}}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		newManagedFieldsEntry("helm", metav1.ManagedFieldsOperationUpdate,
+			`{"f:metadata":{"f:labels":{".":{},"f:app":{}}},"f:spec":{"f:replicas":{}}}`),
+		newManagedFieldsEntry("Terraform-patch", metav1.ManagedFieldsOperationApply,
+			`{"f:metadata":{"f:labels":{"f:team":{}}},"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`),
+	})
+
+	owned, err := ownedFields(obj, "Terraform-patch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+			"labels": map[string]interface{}{
+				"team": "platform",
+			},
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "app",
+							"image": "app:2",
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(owned, expected) {
+		t.Errorf("expected %v, got %v", expected, owned)
+	}
+
+	owned, err = ownedFields(obj, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+	}
+	if !reflect.DeepEqual(owned, expected) {
+		t.Errorf("expected only the identity of the object, got %v", owned)
+	}
+}
+
+func TestListItemMatches(t *testing.T) {
+	samples := map[string]struct {
+		key   string
+		index int
+		item  interface{}
+		match bool
+	}{
+		"key":          {key: `k:{"containerPort":80,"protocol":"TCP"}`, item: map[string]interface{}{"containerPort": int64(80), "protocol": "TCP"}, match: true},
+		"key-mismatch": {key: `k:{"containerPort":80,"protocol":"TCP"}`, item: map[string]interface{}{"containerPort": int64(80), "protocol": "UDP"}},
+		"value":        {key: `v:"foo"`, item: "foo", match: true},
+		"index":        {key: `i:1`, index: 1, item: "foo", match: true},
+		"index-other":  {key: `i:1`, index: 0, item: "foo"},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			if m := listItemMatches(s.key, s.index, s.item); m != s.match {
+				t.Errorf("expected %v, got %v", s.match, m)
+			}
+		})
+	}
+}
+
+func TestReleaseManagedFields(t *testing.T) {
+	entries := []metav1.ManagedFieldsEntry{
+		newManagedFieldsEntry("helm", metav1.ManagedFieldsOperationUpdate, `{}`),
+		newManagedFieldsEntry("Terraform-patch", metav1.ManagedFieldsOperationApply, `{}`),
+	}
+	remaining, ok := releaseManagedFields(entries, "Terraform-patch")
+	if !ok || len(remaining) != 1 || remaining[0].Manager != "helm" {
+		t.Errorf("expected the field manager entry to be released, got %v", remaining)
+	}
+	_, ok = releaseManagedFields(remaining, "Terraform-patch")
+	if ok {
+		t.Error("expected nothing to release")
+	}
+}
+
+func TestMigrateManagedFields(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+		},
+	}}
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		newManagedFieldsEntry("kubectl-client-side-apply", metav1.ManagedFieldsOperationUpdate, `{"f:spec":{"f:replicas":{},"f:paused":{}}}`),
+		newManagedFieldsEntry("kube-controller-manager", metav1.ManagedFieldsOperationUpdate, `{"f:status":{"f:replicas":{}}}`),
+	})
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"}, obj)
+	rs := client.Resource(gvr).Namespace("default")
+
+	manifest := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+		},
+	}
+	migrated, err := migrateManagedFields(context.Background(), rs, "test", []string{"kubectl-client-side-apply"}, "Terraform", "", manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !migrated {
+		t.Fatal("expected the fields to be migrated")
+	}
+	res, err := rs.Get(context.Background(), "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	managers := map[string]metav1.ManagedFieldsOperationType{}
+	for _, e := range res.GetManagedFields() {
+		managers[e.Manager] = e.Operation
+	}
+	expected := map[string]metav1.ManagedFieldsOperationType{
+		"Terraform":                 metav1.ManagedFieldsOperationApply,
+		"kube-controller-manager":   metav1.ManagedFieldsOperationUpdate,
+		"kubectl-client-side-apply": metav1.ManagedFieldsOperationUpdate,
+	}
+	if !reflect.DeepEqual(managers, expected) {
+		t.Fatalf("expected managers %v, got %v", expected, managers)
+	}
+	owned, err := managerFieldSet(res.GetManagedFields(), "Terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOwned := map[string]interface{}{"f:spec": map[string]interface{}{"f:replicas": map[string]interface{}{}}}
+	if !reflect.DeepEqual(owned, expectedOwned) {
+		t.Fatalf("expected Terraform to own the migrated fields of the manifest, got %v", owned)
+	}
+	for _, e := range res.GetManagedFields() {
+		if e.Manager == "kubectl-client-side-apply" && string(e.FieldsV1.Raw) != `{"f:spec":{"f:paused":{}}}` {
+			t.Fatalf("expected the fields not in the manifest to stay with their field manager, got %s", e.FieldsV1.Raw)
+		}
+	}
+
+	migrated, err = migrateManagedFields(context.Background(), rs, "test", []string{"kubectl-client-side-apply"}, "Terraform", "", manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated {
+		t.Fatal("expected nothing left to migrate")
+	}
+}
+
+func TestIntersectFieldSet(t *testing.T) {
+	set := map[string]interface{}{
+		"f:spec": map[string]interface{}{
+			".":          map[string]interface{}{},
+			"f:replicas": map[string]interface{}{},
+			"f:paused":   map[string]interface{}{},
+			"f:template": map[string]interface{}{
+				"f:spec": map[string]interface{}{
+					"f:containers": map[string]interface{}{
+						`k:{"name":"app"}`:     map[string]interface{}{"f:image": map[string]interface{}{}},
+						`k:{"name":"sidecar"}`: map[string]interface{}{"f:image": map[string]interface{}{}},
+					},
+				},
+			},
+		},
+	}
+	v := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "nginx"},
+					},
+				},
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		"f:spec": map[string]interface{}{
+			".":          map[string]interface{}{},
+			"f:replicas": map[string]interface{}{},
+			"f:template": map[string]interface{}{
+				"f:spec": map[string]interface{}{
+					"f:containers": map[string]interface{}{
+						`k:{"name":"app"}`: map[string]interface{}{"f:image": map[string]interface{}{}},
+					},
+				},
+			},
+		},
+	}
+	in := intersectFieldSet(set, v)
+	if !reflect.DeepEqual(in, expected) {
+		t.Fatalf("expected %v, got %v", expected, in)
+	}
+	rest := subtractFieldSet(set, in)
+	expectedRest := map[string]interface{}{
+		"f:spec": map[string]interface{}{
+			"f:paused": map[string]interface{}{},
+			"f:template": map[string]interface{}{
+				"f:spec": map[string]interface{}{
+					"f:containers": map[string]interface{}{
+						`k:{"name":"sidecar"}`: map[string]interface{}{"f:image": map[string]interface{}{}},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(rest, expectedRest) {
+		t.Fatalf("expected %v, got %v", expectedRest, rest)
+	}
+}
+
+func TestOwnedFieldsOnly(t *testing.T) {
+	container := func(name, image string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "image": image}
+	}
+	deployment := func(replicas int64, containers ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "default",
+			},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": containers,
+					},
+				},
+			},
+		}
+	}
+	cfg := deployment(2, container("app", "app:1"))
+	prior := deployment(2, container("app", "app:1"))
+	// the replicas were scaled by an autoscaler, a sidecar was injected, and the image of the app was changed with kubectl
+	live := deployment(5, container("app", "app:2"), container("proxy", "envoy"))
+	set := map[string]interface{}{
+		"f:spec": map[string]interface{}{
+			"f:template": map[string]interface{}{
+				"f:spec": map[string]interface{}{
+					"f:containers": map[string]interface{}{
+						`k:{"name":"app"}`: map[string]interface{}{
+							".":       map[string]interface{}{},
+							"f:name":  map[string]interface{}{},
+							"f:image": map[string]interface{}{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out := ownedFieldsOnly(live, prior, cfg, set)
+	expected := deployment(2, container("app", "app:2"))
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+}
@@ -0,0 +1,578 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+const manifestPatchResourceType = "kubernetes_manifest_patch"
+
+// defaultPatchFieldManagerName is the field manager of 'kubernetes_manifest_patch' resources, unless configured otherwise.
+// It differs from the field manager of 'kubernetes_manifest' so that patches don't take over, or release, the fields of managed resources.
+const defaultPatchFieldManagerName = "Terraform-patch"
+
+// getPatchFieldManagerConfig returns the field manager configuration of a 'kubernetes_manifest_patch' resource
+func (s *RawProviderServer) getPatchFieldManagerConfig(v map[string]tftypes.Value) (string, bool, error) {
+	name, force, err := s.getFieldManagerConfig(v)
+	if err != nil {
+		return "", false, err
+	}
+	if name == defaultFieldManagerName {
+		// configuring the default field manager of 'kubernetes_manifest' is rejected during validation
+		name = defaultPatchFieldManagerName
+	}
+	return name, force, nil
+}
+
+// fieldManagerNameConfigured reports whether the 'field_manager' block configures the given field manager name
+func fieldManagerNameConfigured(v map[string]tftypes.Value, name string) bool {
+	fm := v["field_manager"]
+	if fm.IsNull() || !fm.IsKnown() {
+		return false
+	}
+	var blocks []tftypes.Value
+	if err := fm.As(&blocks); err != nil || len(blocks) == 0 {
+		return false
+	}
+	var atts map[string]tftypes.Value
+	if err := blocks[0].As(&atts); err != nil {
+		return false
+	}
+	var n string
+	if !atts["name"].IsKnown() || atts["name"].IsNull() || atts["name"].As(&n) != nil {
+		return false
+	}
+	return n == name
+}
+
+// manifestPatchTarget returns the object a patch manifest applies to, along with a client for its API resource
+func (s *RawProviderServer) manifestPatchTarget(manifest tftypes.Value) (map[string]interface{}, manifestsObject, dynamic.ResourceInterface, error) {
+	pu, err := payload.FromTFValue(manifest, nil, tftypes.NewAttributePath())
+	if err != nil {
+		return nil, manifestsObject{}, nil, err
+	}
+	content, ok := pu.(map[string]interface{})
+	if !ok {
+		return nil, manifestsObject{}, nil, fmt.Errorf("the manifest is not an object")
+	}
+	content = mapRemoveNulls(content)
+	uo := unstructured.Unstructured{Object: content}
+	o := manifestsObject{
+		apiVersion: uo.GetAPIVersion(),
+		kind:       uo.GetKind(),
+		name:       uo.GetName(),
+		namespace:  uo.GetNamespace(),
+	}
+	rs, ns, err := s.manifestsResourceClient(o)
+	if err != nil {
+		return nil, o, nil, err
+	}
+	if ns != "" {
+		o.namespace = ns
+		uo.SetNamespace(ns)
+	}
+	return content, o, rs, nil
+}
+
+// manifestPatchApplied reports whether all the values set in a patch manifest are present in the owned fields of the object
+func manifestPatchApplied(object, manifest tftypes.Value) bool {
+	if object.IsNull() || !object.IsFullyKnown() {
+		return false
+	}
+	ou, err := payload.FromTFValue(object, nil, tftypes.NewAttributePath())
+	if err != nil {
+		return false
+	}
+	mu, err := payload.FromTFValue(manifest, nil, tftypes.NewAttributePath())
+	if err != nil {
+		return false
+	}
+	return containsValues(ou, mu)
+}
+
+// containsValues reports whether all the non-null values of 'sub' are present in 'v'
+func containsValues(v, sub interface{}) bool {
+	switch ts := sub.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		tv, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, e := range ts {
+			if e == nil {
+				continue
+			}
+			if !containsValues(tv[k], e) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		tv, ok := v.([]interface{})
+		if !ok || len(tv) != len(ts) {
+			return false
+		}
+		for i := range ts {
+			if !containsValues(tv[i], ts[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return jsonEqual(v, sub)
+}
+
+func (s *RawProviderServer) validateManifestPatch(req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	config, err := req.Config.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	configVal := make(map[string]tftypes.Value)
+	if err := config.As(&configVal); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract resource state from SDK value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	if fieldManagerNameConfigured(configVal, defaultFieldManagerName) {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid field manager",
+			Detail:    fmt.Sprintf("The %q field manager is used by kubernetes_manifest resources and cannot be used to patch resources.", defaultFieldManagerName),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("field_manager"),
+		})
+	}
+
+	att := tftypes.NewAttributePath().WithAttributeName("manifest")
+	rawManifest := make(map[string]tftypes.Value)
+	if err := configVal["manifest"].As(&rawManifest); err != nil {
+		// the manifest still contains unknown values
+		return resp, nil
+	}
+	for _, key := range []string{"apiVersion", "kind", "metadata"} {
+		if _, present := rawManifest[key]; !present {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   `Attribute key missing from "manifest" value`,
+				Detail:    fmt.Sprintf("'%s' attribute key is missing from manifest configuration", key),
+				Attribute: att.WithAttributeName(key),
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (s *RawProviderServer) planManifestPatch(req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp := &tfprotov5.PlanResourceChangeResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine planned resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	proposedState, err := req.ProposedNewState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal planned resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	priorState, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal prior resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if proposedState.IsNull() {
+		// we plan to release the patched fields
+		resp.PlannedState = req.ProposedNewState
+		return resp, nil
+	}
+	proposedVal := make(map[string]tftypes.Value)
+	err = proposedState.As(&proposedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract planned resource state from tftypes.Value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace,
+		tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("apiVersion"),
+		tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("kind"),
+		tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata").WithAttributeName("name"),
+		tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata").WithAttributeName("namespace"),
+		// the fields applied by another field manager are released by replacing the resource
+		tftypes.NewAttributePath().WithAttributeName("field_manager"),
+	)
+
+	// The owned fields are only known after apply, unless the patch is unchanged and still
+	// applied to the object. Otherwise the object has drifted and the patch is applied again.
+	manifest := proposedVal["manifest"]
+	proposedVal["object"] = tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue)
+	if !priorState.IsNull() && manifest.IsFullyKnown() {
+		priorVal := make(map[string]tftypes.Value)
+		if err := priorState.As(&priorVal); err == nil {
+			if priorVal["manifest"].Equal(manifest) && manifestPatchApplied(priorVal["object"], manifest) {
+				proposedVal["object"] = priorVal["object"]
+			}
+		}
+	}
+
+	propStateVal := tftypes.NewValue(proposedState.Type(), proposedVal)
+	plannedState, err := tfprotov5.NewDynamicValue(propStateVal.Type(), propStateVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to assemble proposed state during plan",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	resp.PlannedState = &plannedState
+	return resp, nil
+}
+
+func (s *RawProviderServer) applyManifestPatch(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp := &tfprotov5.ApplyResourceChangeResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine planned resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	plannedState, err := req.PlannedState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal planned resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	priorState, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal prior resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	if plannedState.IsNull() {
+		priorVal := make(map[string]tftypes.Value)
+		if err := priorState.As(&priorVal); err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to extract prior resource state values",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		timeout, _ := time.ParseDuration(s.getTimeouts(priorVal)["delete"])
+		ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		resp.Diagnostics = append(resp.Diagnostics, s.releaseManifestPatch(ctxDeadline, priorVal)...)
+		if len(resp.Diagnostics) > 0 {
+			return resp, nil
+		}
+		resp.NewState = req.PlannedState
+		return resp, nil
+	}
+
+	plannedVal := make(map[string]tftypes.Value)
+	err = plannedState.As(&plannedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract planned resource state values",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	content, o, rs, err := s.manifestPatchTarget(plannedVal["manifest"])
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Failed to determine the resource to patch",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("manifest"),
+		})
+		return resp, nil
+	}
+	fieldManagerName, forceConflicts, err := s.getPatchFieldManagerConfig(plannedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Could not extract field_manager config",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	timeouts := s.getTimeouts(plannedVal)
+	timeout, _ := time.ParseDuration(timeouts["update"])
+	if priorState.IsNull() {
+		timeout, _ = time.ParseDuration(timeouts["create"])
+	}
+	ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// a server-side apply would create the resource if it doesn't exist
+	if _, err := rs.Get(ctxDeadline, o.name, metav1.GetOptions{}); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf("Failed to get %s to patch", o),
+			Detail:   fmt.Sprintf("Only existing resources can be patched.\nError: %s", err),
+		})
+		return resp, nil
+	}
+
+	js, err := json.Marshal(content)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf("Failed to marshall %s to JSON", o),
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	s.logger.Trace("[ApplyResourceChange][kubernetes_manifest_patch][API Payload]: %s", js)
+	result, err := rs.Patch(ctxDeadline, o.name, types.ApplyPatchType, js,
+		metav1.PatchOptions{
+			FieldManager: fieldManagerName,
+			Force:        &forceConflicts,
+		},
+	)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, applyErrorToDiagnostics(err, o.String(), content, tftypes.NewAttributePath().WithAttributeName("manifest"))...)
+		return resp, nil
+	}
+
+	obj, err := manifestPatchObject(result, fieldManagerName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine the fields owned by the field manager",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	plannedVal["object"] = obj
+
+	newState, err := tfprotov5.NewDynamicValue(plannedState.Type(), tftypes.NewValue(plannedState.Type(), plannedVal))
+	if err != nil {
+		return resp, err
+	}
+	resp.NewState = &newState
+	return resp, nil
+}
+
+// manifestPatchObject returns the fields of an object owned by the field manager, as the 'object' attribute value
+func manifestPatchObject(obj *unstructured.Unstructured, manager string) (tftypes.Value, error) {
+	owned, err := ownedFields(obj, manager)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return payload.ToTFValue(mapRemoveNulls(owned), tftypes.DynamicPseudoType, map[string]string{}, tftypes.NewAttributePath())
+}
+
+// releaseManifestPatch releases the ownership of the fields applied by a patch, by removing the managedFields entry
+// of its field manager. Unlike applying an empty patch, this leaves the values of the fields in place.
+func (s *RawProviderServer) releaseManifestPatch(ctx context.Context, priorVal map[string]tftypes.Value) []*tfprotov5.Diagnostic {
+	_, o, rs, err := s.manifestPatchTarget(priorVal["manifest"])
+	if err != nil {
+		return []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine the patched resource",
+			Detail:   err.Error(),
+		}}
+	}
+	fieldManagerName, _, err := s.getPatchFieldManagerConfig(priorVal)
+	if err != nil {
+		return []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Could not extract field_manager config",
+			Detail:   err.Error(),
+		}}
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := rs.Get(ctx, o.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		remaining, ok := releaseManagedFields(obj.GetManagedFields(), fieldManagerName)
+		if !ok {
+			return nil
+		}
+		if len(remaining) == 0 {
+			// an empty list leaves managedFields unchanged, a list with an empty entry clears them
+			remaining = []metav1.ManagedFieldsEntry{{}}
+		}
+		js, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"managedFields":   remaining,
+				"resourceVersion": obj.GetResourceVersion(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = rs.Patch(ctx, o.name, types.MergePatchType, js, metav1.PatchOptions{})
+		return err
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf("Failed to release the fields of %s", o),
+			Detail:   err.Error(),
+		}}
+	}
+	return nil
+}
+
+func (s *RawProviderServer) readManifestPatch(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	resp := &tfprotov5.ReadResourceResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	currentState, err := req.CurrentState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to decode current state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if currentState.IsNull() {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to read resource",
+			Detail:   "Incomplete of missing state",
+		})
+		return resp, nil
+	}
+	stateVal := make(map[string]tftypes.Value)
+	if err := currentState.As(&stateVal); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract resource from current state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	_, o, rs, err := s.manifestPatchTarget(stateVal["manifest"])
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine the patched resource",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	fieldManagerName, _, err := s.getPatchFieldManagerConfig(stateVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Could not extract field_manager config",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	ro, err := rs.Get(ctx, o.name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the patched resource is gone, and the patch with it
+			return resp, nil
+		}
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  fmt.Sprintf("Cannot GET resource %s", o),
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	obj, err := manifestPatchObject(ro, fieldManagerName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine the fields owned by the field manager",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	stateVal["object"] = obj
+
+	nsVal := tftypes.NewValue(currentState.Type(), stateVal)
+	newState, err := tfprotov5.NewDynamicValue(nsVal.Type(), nsVal)
+	if err != nil {
+		return resp, err
+	}
+	resp.NewState = &newState
+	resp.Private = req.Private
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestContainsValues(t *testing.T) {
+	v := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"ports": []interface{}{
+				map[string]interface{}{"port": int64(80), "name": "http"},
+			},
+		},
+	}
+	samples := map[string]struct {
+		sub      interface{}
+		contains bool
+	}{
+		"subset": {
+			sub: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": float64(2)},
+			},
+			contains: true,
+		},
+		"list": {
+			sub: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"ports": []interface{}{
+						map[string]interface{}{"port": int64(80)},
+					},
+				},
+			},
+			contains: true,
+		},
+		"null": {
+			sub: map[string]interface{}{
+				"spec": map[string]interface{}{"paused": nil},
+			},
+			contains: true,
+		},
+		"changed": {
+			sub: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(3)},
+			},
+		},
+		"missing": {
+			sub: map[string]interface{}{
+				"spec": map[string]interface{}{"paused": true},
+			},
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			if c := containsValues(v, s.sub); c != s.contains {
+				t.Errorf("expected %v, got %v", s.contains, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/framework/provider/functions"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
+)
+
+// decodeManifestYAML decodes the single Kubernetes manifest in a YAML document
+func decodeManifestYAML(manifest string) (map[string]interface{}, error) {
+	docs, err := functions.DecodeDocuments(manifest)
+	if err != nil {
+		return nil, err
+	}
+	switch len(docs) {
+	case 0:
+		return nil, fmt.Errorf("the YAML document does not contain a manifest")
+	case 1:
+		return docs[0], nil
+	}
+	return nil, fmt.Errorf("the YAML document contains %d manifests, but only one is allowed. Use the kubernetes_manifests resource to apply several manifests", len(docs))
+}
+
+// manifestValue returns the manifest of a 'kubernetes_manifest' resource, from either the 'manifest'
+// or the 'manifest_yaml' attribute. YAML manifests are converted to the same shape as HCL manifests,
+// with values typed according to the resource type 'st' and type hints 'th', when they are known.
+func manifestValue(v map[string]tftypes.Value, st tftypes.Type, th map[string]string) (tftypes.Value, error) {
+	my, ok := v["manifest_yaml"]
+	if !ok || my.IsNull() {
+		return v["manifest"], nil
+	}
+	if !my.IsKnown() {
+		return tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue), nil
+	}
+	var y string
+	if err := my.As(&y); err != nil {
+		return tftypes.Value{}, err
+	}
+	obj, err := decodeManifestYAML(y)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return payload.FromYAMLValue(obj, st, th, tftypes.NewAttributePath())
+}
+
+// manifestAttribute returns the name of the attribute the manifest of a 'kubernetes_manifest' resource is configured with
+func manifestAttribute(v map[string]tftypes.Value) string {
+	if my, ok := v["manifest_yaml"]; ok && !my.IsNull() {
+		return "manifest_yaml"
+	}
+	return "manifest"
+}
+
+// manifestIdentityChanged reports whether two manifests describe different objects, which requires replacing the resource
+func manifestIdentityChanged(prior, planned tftypes.Value, namespaced bool) bool {
+	paths := []*tftypes.AttributePath{
+		tftypes.NewAttributePath().WithAttributeName("apiVersion"),
+		tftypes.NewAttributePath().WithAttributeName("kind"),
+		tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("name"),
+	}
+	if namespaced {
+		paths = append(paths, tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("namespace"))
+	}
+	for _, p := range paths {
+		pv, _, perr := tftypes.WalkAttributePath(prior, p)
+		nv, _, nerr := tftypes.WalkAttributePath(planned, p)
+		if (perr == nil) != (nerr == nil) {
+			return true
+		}
+		if perr == nil && !pv.(tftypes.Value).Equal(nv.(tftypes.Value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDecodeManifestYAML(t *testing.T) {
+	obj, err := decodeManifestYAML("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj["kind"] != "ConfigMap" {
+		t.Fatalf("unexpected manifest: %v", obj)
+	}
+
+	_, err = decodeManifestYAML("apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: Secret\n")
+	if err == nil {
+		t.Fatal("expected an error for a YAML document with several manifests")
+	}
+	_, err = decodeManifestYAML("# empty\n")
+	if err == nil {
+		t.Fatal("expected an error for a YAML document without a manifest")
+	}
+}
+
+func TestManifestValue(t *testing.T) {
+	v := map[string]tftypes.Value{
+		"manifest":      tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+		"manifest_yaml": tftypes.NewValue(tftypes.String, "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n"),
+	}
+	if a := manifestAttribute(v); a != "manifest_yaml" {
+		t.Fatalf("expected manifest_yaml attribute, got %q", a)
+	}
+	man, err := manifestValue(v, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, _, err := tftypes.WalkAttributePath(man, tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !name.(tftypes.Value).Equal(tftypes.NewValue(tftypes.String, "test")) {
+		t.Fatalf("unexpected name: %s", name)
+	}
+
+	v["manifest_yaml"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	man, err = manifestValue(v, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if man.IsKnown() {
+		t.Fatalf("expected unknown manifest, got %s", man)
+	}
+}
+
+func TestManifestIdentityChanged(t *testing.T) {
+	manifest := func(name, namespace string) tftypes.Value {
+		v, err := manifestValue(map[string]tftypes.Value{
+			"manifest_yaml": tftypes.NewValue(tftypes.String,
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: "+name+"\n  namespace: "+namespace+"\n"),
+		}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	if manifestIdentityChanged(manifest("test", "default"), manifest("test", "default"), true) {
+		t.Error("expected identical manifests to have the same identity")
+	}
+	if !manifestIdentityChanged(manifest("test", "default"), manifest("other", "default"), true) {
+		t.Error("expected renamed manifest to change identity")
+	}
+	if !manifestIdentityChanged(manifest("test", "default"), manifest("test", "other"), true) {
+		t.Error("expected manifest in another namespace to change identity")
+	}
+	if manifestIdentityChanged(manifest("test", "default"), manifest("test", "other"), false) {
+		t.Error("expected namespace to be ignored for cluster-scoped resources")
+	}
+}
@@ -0,0 +1,796 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/framework/provider/functions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const manifestsResourceType = "kubernetes_manifests"
+
+// manifestsObjectType is the type of the elements of the 'objects' attribute of 'kubernetes_manifests'
+var manifestsObjectType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"api_version": tftypes.String,
+		"kind":        tftypes.String,
+		"name":        tftypes.String,
+		"namespace":   tftypes.String,
+		"drifted":     tftypes.Bool,
+	},
+}
+
+// manifestsObject is an object managed by a 'kubernetes_manifests' resource
+type manifestsObject struct {
+	apiVersion string
+	kind       string
+	name       string
+	namespace  string
+	// drifted is set when fields of the manifest of the object were changed outside of Terraform
+	drifted bool
+	// content is only set for objects decoded from configuration
+	content map[string]interface{}
+}
+
+func (o manifestsObject) groupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(o.apiVersion, o.kind)
+}
+
+// key identifies the object regardless of its API version
+func (o manifestsObject) key() string {
+	return strings.Join([]string{o.groupVersionKind().GroupKind().String(), o.namespace, o.name}, "/")
+}
+
+func (o manifestsObject) String() string {
+	rnn := types.NamespacedName{Namespace: o.namespace, Name: o.name}.String()
+	return fmt.Sprintf("%s %s", o.groupVersionKind().GroupKind(), rnn)
+}
+
+// applyPriority orders objects so that CRDs and Namespaces are created before the objects that depend on them
+func (o manifestsObject) applyPriority() int {
+	gk := o.groupVersionKind().GroupKind()
+	switch gk {
+	case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+		return 0
+	case schema.GroupKind{Group: "", Kind: "Namespace"}:
+		return 1
+	}
+	return 2
+}
+
+func (o manifestsObject) toValue() tftypes.Value {
+	ns := tftypes.NewValue(tftypes.String, nil)
+	if o.namespace != "" {
+		ns = tftypes.NewValue(tftypes.String, o.namespace)
+	}
+	return tftypes.NewValue(manifestsObjectType, map[string]tftypes.Value{
+		"api_version": tftypes.NewValue(tftypes.String, o.apiVersion),
+		"kind":        tftypes.NewValue(tftypes.String, o.kind),
+		"name":        tftypes.NewValue(tftypes.String, o.name),
+		"namespace":   ns,
+		"drifted":     tftypes.NewValue(tftypes.Bool, o.drifted),
+	})
+}
+
+func manifestsObjectsToValue(objs []manifestsObject) tftypes.Value {
+	vals := make([]tftypes.Value, len(objs))
+	for i, o := range objs {
+		vals[i] = o.toValue()
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: manifestsObjectType}, vals)
+}
+
+func manifestsObjectsFromValue(v tftypes.Value) ([]manifestsObject, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+	var vals []tftypes.Value
+	if err := v.As(&vals); err != nil {
+		return nil, err
+	}
+	objs := make([]manifestsObject, 0, len(vals))
+	for _, ov := range vals {
+		var atts map[string]tftypes.Value
+		if err := ov.As(&atts); err != nil {
+			return nil, err
+		}
+		var o manifestsObject
+		var ns *string
+		for k, p := range map[string]*string{"api_version": &o.apiVersion, "kind": &o.kind, "name": &o.name} {
+			if err := atts[k].As(p); err != nil {
+				return nil, err
+			}
+		}
+		if err := atts["namespace"].As(&ns); err != nil {
+			return nil, err
+		}
+		if ns != nil {
+			o.namespace = *ns
+		}
+		if v := atts["drifted"]; !v.IsNull() && v.IsKnown() {
+			if err := v.As(&o.drifted); err != nil {
+				return nil, err
+			}
+		}
+		objs = append(objs, o)
+	}
+	return objs, nil
+}
+
+// decodeManifests decodes the objects in a stream of YAML manifests
+func decodeManifests(content string) ([]manifestsObject, error) {
+	docs, err := functions.DecodeDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]manifestsObject, 0, len(docs))
+	seen := make(map[string]int, len(docs))
+	for i, d := range docs {
+		u := unstructured.Unstructured{Object: d}
+		o := manifestsObject{
+			apiVersion: u.GetAPIVersion(),
+			kind:       u.GetKind(),
+			name:       u.GetName(),
+			namespace:  u.GetNamespace(),
+			content:    d,
+		}
+		if o.apiVersion == "" || o.kind == "" {
+			return nil, fmt.Errorf("manifest #%d: 'apiVersion' and 'kind' must be set", i+1)
+		}
+		if o.name == "" {
+			return nil, fmt.Errorf("manifest #%d (%s): 'metadata.name' must be set", i+1, o.kind)
+		}
+		if j, ok := seen[o.key()]; ok {
+			return nil, fmt.Errorf("manifest #%d (%s) is a duplicate of manifest #%d", i+1, o, j+1)
+		}
+		seen[o.key()] = i
+		objs = append(objs, o)
+	}
+	return objs, nil
+}
+
+// manifestsApplyOrder returns the objects in the order they should be applied in
+func manifestsApplyOrder(objs []manifestsObject) []manifestsObject {
+	ordered := make([]manifestsObject, len(objs))
+	copy(ordered, objs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].applyPriority() < ordered[j].applyPriority()
+	})
+	return ordered
+}
+
+// manifestsResourceClient returns a client for the API resource of an object, along with the namespace it lives in.
+// Namespaced objects without a namespace are placed in the "default" namespace.
+func (s *RawProviderServer) manifestsResourceClient(o manifestsObject) (dynamic.ResourceInterface, string, error) {
+	c, err := s.getDynamicClient()
+	if err != nil {
+		return nil, "", err
+	}
+	m, err := s.getRestMapper()
+	if err != nil {
+		return nil, "", err
+	}
+	gvk := o.groupVersionKind()
+	mapping, err := m.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the kind may have been created by a CRD applied moments ago
+		if rm, ok := m.(meta.ResettableRESTMapper); ok {
+			rm.Reset()
+			mapping, err = m.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return c.Resource(mapping.Resource), "", nil
+	}
+	ns := o.namespace
+	if ns == "" {
+		ns = "default"
+	}
+	return c.Resource(mapping.Resource).Namespace(ns), ns, nil
+}
+
+// waitForCRDEstablished waits until the API server has started serving the kinds defined by a CRD
+func waitForCRDEstablished(ctx context.Context, rs dynamic.ResourceInterface, name string) error {
+	for {
+		crd, err := rs.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+		for _, c := range conditions {
+			cm, ok := c.(map[string]interface{})
+			if ok && cm["type"] == "Established" && cm["status"] == "True" {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for CustomResourceDefinition %q to be established", name)
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+// deleteManifestsObjects deletes objects in the reverse order they were applied in and waits for them to be gone.
+// It returns the objects that could not be deleted.
+func (s *RawProviderServer) deleteManifestsObjects(ctx context.Context, objs []manifestsObject) ([]manifestsObject, []*tfprotov5.Diagnostic) {
+	type pendingDelete struct {
+		obj manifestsObject
+		rs  dynamic.ResourceInterface
+	}
+	var diags []*tfprotov5.Diagnostic
+	var remaining []manifestsObject
+	var pending []pendingDelete
+
+	ordered := manifestsApplyOrder(objs)
+	for i := len(ordered) - 1; i >= 0; i-- {
+		o := ordered[i]
+		rs, _, err := s.manifestsResourceClient(o)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// the CRD is gone, and with it all of its objects
+				continue
+			}
+			remaining = append(remaining, o)
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Failed to delete %s", o),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		err = rs.Delete(ctx, o.name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			remaining = append(remaining, o)
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Failed to delete %s", o),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		pending = append(pending, pendingDelete{obj: o, rs: rs})
+	}
+
+	for len(pending) > 0 {
+		var stillPending []pendingDelete
+		for _, p := range pending {
+			_, err := p.rs.Get(ctx, p.obj.name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			stillPending = append(stillPending, p)
+		}
+		pending = stillPending
+		if len(pending) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			names := make([]string, len(pending))
+			for i, p := range pending {
+				remaining = append(remaining, p.obj)
+				names[i] = p.obj.String()
+			}
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Timed out when waiting for objects to be deleted",
+				Detail:   fmt.Sprintf("Deletion timed out for: %s. This can happen when there is a finalizer on a resource. You may need to delete these resources manually with kubectl.", strings.Join(names, ", ")),
+			})
+			return remaining, diags
+		case <-time.After(1 * time.Second): // lintignore:R018
+		}
+	}
+	return remaining, diags
+}
+
+// manifestsObjectDrifted reports whether fields of the manifest of an object are no longer owned by the field manager
+// that applied them. Another field manager takes over the ownership of the fields it changes, and fields removed
+// from the object are removed from the field set of their owner.
+func (s *RawProviderServer) manifestsObjectDrifted(live *unstructured.Unstructured, manifest map[string]interface{}, fieldManager string) (bool, error) {
+	set, err := managerFieldSet(live.GetManagedFields(), fieldManager)
+	if err != nil {
+		return false, err
+	}
+	// the default metadata of the provider is expected on the object as well
+	s.setProviderMetadata(manifest)
+	fields := make(map[string]interface{}, len(manifest))
+	for k, v := range manifest {
+		// the fields identifying the object are not part of the managed fields,
+		// and the status is ignored when applying the object itself
+		if k == "apiVersion" || k == "kind" || k == "status" {
+			continue
+		}
+		fields[k] = v
+	}
+	if md, ok := manifest["metadata"].(map[string]interface{}); ok {
+		m := make(map[string]interface{}, len(md))
+		for k, v := range md {
+			if k == "name" || k == "namespace" {
+				continue
+			}
+			m[k] = v
+		}
+		delete(fields, "metadata")
+		if len(m) > 0 {
+			fields["metadata"] = m
+		}
+	}
+	return !fieldSetCovers(set, fields), nil
+}
+
+func (s *RawProviderServer) validateManifests(req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	config, err := req.Config.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	configVal := make(map[string]tftypes.Value)
+	err = config.As(&configVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract resource state from SDK value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	content := configVal["content"]
+	if content.IsNull() || !content.IsKnown() {
+		return resp, nil
+	}
+	var cs string
+	content.As(&cs)
+	if _, err := decodeManifests(cs); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid manifests",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("content"),
+		})
+	}
+	return resp, nil
+}
+
+func (s *RawProviderServer) planManifests(req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp := &tfprotov5.PlanResourceChangeResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine planned resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	proposedState, err := req.ProposedNewState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal planned resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if proposedState.IsNull() {
+		// we plan to delete the resource
+		resp.PlannedState = req.ProposedNewState
+		return resp, nil
+	}
+	proposedVal := make(map[string]tftypes.Value)
+	err = proposedState.As(&proposedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract planned resource state from tftypes.Value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	// the objects are entirely determined by the content, so they can be planned without contacting the API server
+	content := proposedVal["content"]
+	if !content.IsKnown() {
+		proposedVal["objects"] = tftypes.NewValue(tftypes.List{ElementType: manifestsObjectType}, tftypes.UnknownValue)
+	} else {
+		var cs string
+		content.As(&cs)
+		objs, err := decodeManifests(cs)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid manifests",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("content"),
+			})
+			return resp, nil
+		}
+		proposedVal["objects"] = manifestsObjectsToValue(objs)
+	}
+
+	propStateVal := tftypes.NewValue(proposedState.Type(), proposedVal)
+	plannedState, err := tfprotov5.NewDynamicValue(propStateVal.Type(), propStateVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to assemble proposed state during plan",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	resp.PlannedState = &plannedState
+	return resp, nil
+}
+
+func (s *RawProviderServer) applyManifests(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp := &tfprotov5.ApplyResourceChangeResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine planned resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	plannedState, err := req.PlannedState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal planned resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	priorState, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal prior resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	priorVal := make(map[string]tftypes.Value)
+	var priorObjs []manifestsObject
+	if !priorState.IsNull() {
+		err = priorState.As(&priorVal)
+		if err == nil {
+			priorObjs, err = manifestsObjectsFromValue(priorVal["objects"])
+		}
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to extract prior resource state values",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+	}
+
+	if plannedState.IsNull() {
+		// Delete all objects
+		timeout, _ := time.ParseDuration(s.getTimeouts(priorVal)["delete"])
+		ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		remaining, diags := s.deleteManifestsObjects(ctxDeadline, priorObjs)
+		resp.Diagnostics = append(resp.Diagnostics, diags...)
+		if len(remaining) > 0 {
+			// keep track of the objects we failed to delete
+			priorVal["objects"] = manifestsObjectsToValue(remaining)
+			return resp, setManifestsNewState(resp, priorState.Type(), priorVal)
+		}
+		resp.NewState = req.PlannedState
+		return resp, nil
+	}
+
+	plannedVal := make(map[string]tftypes.Value)
+	err = plannedState.As(&plannedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract planned resource state values",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	var content string
+	plannedVal["content"].As(&content)
+	objs, err := decodeManifests(content)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid manifests",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("content"),
+		})
+		return resp, nil
+	}
+
+	fieldManagerName, forceConflicts, err := s.getFieldManagerConfig(plannedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Could not extract field_manager config",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	timeouts := s.getTimeouts(plannedVal)
+	var timeout time.Duration
+	if priorState.IsNull() {
+		timeout, _ = time.ParseDuration(timeouts["create"])
+	} else {
+		timeout, _ = time.ParseDuration(timeouts["update"])
+	}
+	ctxDeadline, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// objects are identified by their resolved namespace, so that moving an object
+	// from the implicit "default" namespace to an explicit one doesn't prune it
+	applied := make(map[string]bool, len(objs))
+	resolvedKey := func(o manifestsObject, ns string) string {
+		o.namespace = ns
+		return o.key()
+	}
+
+	var appliedObjs []manifestsObject
+	var pendingCRDs []manifestsObject
+	for _, o := range manifestsApplyOrder(objs) {
+		if len(pendingCRDs) > 0 && o.applyPriority() > 0 {
+			// make sure the API server serves the new kinds before applying objects of those kinds
+			for _, crd := range pendingCRDs {
+				rs, _, err := s.manifestsResourceClient(crd)
+				if err == nil {
+					err = waitForCRDEstablished(ctxDeadline, rs, crd.name)
+				}
+				if err != nil {
+					resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  fmt.Sprintf("Failed waiting for %s to be established", crd),
+						Detail:   err.Error(),
+					})
+					return resp, s.setManifestsPartialState(resp, plannedState.Type(), plannedVal, priorObjs, appliedObjs)
+				}
+			}
+			pendingCRDs = nil
+			if m, err := s.getRestMapper(); err == nil {
+				if rm, ok := m.(meta.ResettableRESTMapper); ok {
+					rm.Reset()
+				}
+			}
+		}
+
+		rs, ns, err := s.manifestsResourceClient(o)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Failed to determine the type of %s", o),
+				Detail:   err.Error(),
+			})
+			return resp, s.setManifestsPartialState(resp, plannedState.Type(), plannedVal, priorObjs, appliedObjs)
+		}
+
+		s.setProviderMetadata(o.content)
+		s.logger.Trace("[ApplyResourceChange][kubernetes_manifests][API Payload]", "object", dump(o.content))
+		_, err = serverSideApply(ctxDeadline, rs, &unstructured.Unstructured{Object: o.content}, fieldManagerName, forceConflicts)
+		if err != nil {
+			s.logger.Error("[ApplyResourceChange][kubernetes_manifests]", "API error", dump(err))
+			rnn := types.NamespacedName{Namespace: ns, Name: o.name}.String()
+			resp.Diagnostics = append(resp.Diagnostics, applyErrorToDiagnostics(err, rnn, nil, nil)...)
+			return resp, s.setManifestsPartialState(resp, plannedState.Type(), plannedVal, priorObjs, appliedObjs)
+		}
+		applied[resolvedKey(o, ns)] = true
+		appliedObjs = append(appliedObjs, o)
+		if o.applyPriority() == 0 {
+			pendingCRDs = append(pendingCRDs, o)
+		}
+	}
+
+	// prune objects that were removed from the content
+	var stale []manifestsObject
+	for _, o := range priorObjs {
+		_, ns, err := s.manifestsResourceClient(o)
+		if err == nil && applied[resolvedKey(o, ns)] {
+			continue
+		}
+		stale = append(stale, o)
+	}
+	remaining, diags := s.deleteManifestsObjects(ctxDeadline, stale)
+	resp.Diagnostics = append(resp.Diagnostics, diags...)
+
+	plannedVal["objects"] = manifestsObjectsToValue(append(objs, remaining...))
+	return resp, setManifestsNewState(resp, plannedState.Type(), plannedVal)
+}
+
+// setManifestsPartialState records the objects applied before a failure in the new state,
+// along with the objects from the prior state which haven't been pruned yet
+func (s *RawProviderServer) setManifestsPartialState(resp *tfprotov5.ApplyResourceChangeResponse, t tftypes.Type, val map[string]tftypes.Value, prior, applied []manifestsObject) error {
+	objs := append([]manifestsObject{}, prior...)
+	known := make(map[string]bool, len(prior))
+	for _, o := range prior {
+		known[o.key()] = true
+	}
+	for _, o := range applied {
+		if !known[o.key()] {
+			objs = append(objs, o)
+		}
+	}
+	val["objects"] = manifestsObjectsToValue(objs)
+	return setManifestsNewState(resp, t, val)
+}
+
+func setManifestsNewState(resp *tfprotov5.ApplyResourceChangeResponse, t tftypes.Type, val map[string]tftypes.Value) error {
+	newState, err := tfprotov5.NewDynamicValue(t, tftypes.NewValue(t, val))
+	if err != nil {
+		return err
+	}
+	resp.NewState = &newState
+	return nil
+}
+
+func (s *RawProviderServer) readManifests(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	resp := &tfprotov5.ReadResourceResponse{}
+
+	rt, err := GetResourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine resource type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	currentState, err := req.CurrentState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to decode current state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if currentState.IsNull() {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to read resource",
+			Detail:   "Incomplete of missing state",
+		})
+		return resp, nil
+	}
+	stateVal := make(map[string]tftypes.Value)
+	err = currentState.As(&stateVal)
+	var objs []manifestsObject
+	if err == nil {
+		objs, err = manifestsObjectsFromValue(stateVal["objects"])
+	}
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract resource from current state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	// the manifests of the objects, to detect the objects whose fields were changed outside of Terraform
+	manifests := make(map[string]map[string]interface{}, len(objs))
+	if content := stateVal["content"]; !content.IsNull() && content.IsKnown() {
+		var cs string
+		content.As(&cs)
+		decoded, err := decodeManifests(cs)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid manifests",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("content"),
+			})
+			return resp, nil
+		}
+		for _, o := range decoded {
+			manifests[o.key()] = o.content
+		}
+	}
+	fieldManagerName, _, err := s.getFieldManagerConfig(stateVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Could not extract field_manager config",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	// drop objects which no longer exist, so that the next plan re-creates them
+	existing := make([]manifestsObject, 0, len(objs))
+	for _, o := range objs {
+		var live *unstructured.Unstructured
+		rs, _, err := s.manifestsResourceClient(o)
+		if err == nil {
+			live, err = rs.Get(ctx, o.name, metav1.GetOptions{})
+		}
+		if err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				s.logger.Debug("[ReadResource][kubernetes_manifests]", "object not found", o.String())
+				continue
+			}
+			var status apierrors.APIStatus
+			if errors.As(err, &status) {
+				resp.Diagnostics = append(resp.Diagnostics, APIStatusErrorToDiagnostics(status.Status())...)
+				return resp, nil
+			}
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Cannot GET %s", o),
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		if m, ok := manifests[o.key()]; ok {
+			drifted, err := s.manifestsObjectDrifted(live, m, fieldManagerName)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  fmt.Sprintf("Failed to compare %s with its manifest", o),
+					Detail:   err.Error(),
+				})
+				return resp, nil
+			}
+			if drifted {
+				s.logger.Debug("[ReadResource][kubernetes_manifests]", "object changed outside of Terraform", o.String())
+			}
+			o.drifted = drifted
+		}
+		existing = append(existing, o)
+	}
+
+	stateVal["objects"] = manifestsObjectsToValue(existing)
+	newState, err := tfprotov5.NewDynamicValue(currentState.Type(), tftypes.NewValue(currentState.Type(), stateVal))
+	if err != nil {
+		return resp, err
+	}
+	resp.NewState = &newState
+	resp.Private = req.Private
+	return resp, nil
+}
@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const testManifestsContent = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: operator-system
+spec:
+  replicas: 1
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: operator-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  size: "1000000"
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+`
+
+func TestDecodeManifests(t *testing.T) {
+	objs, err := decodeManifests(testManifestsContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range objs {
+		got = append(got, o.String())
+	}
+	expected := []string{
+		"Deployment.apps operator-system/operator",
+		"Namespace /operator-system",
+		"ConfigMap /settings",
+		"CustomResourceDefinition.apiextensions.k8s.io /widgets.example.com",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("object #%d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+
+	js, err := json.Marshal(objs[0].content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d struct {
+		Spec struct {
+			Replicas int32 `json:"replicas"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(js, &d); err != nil {
+		t.Fatalf("integer values must survive encoding: %s", err)
+	}
+
+	ordered := manifestsApplyOrder(objs)
+	for i, k := range []string{"CustomResourceDefinition", "Namespace", "Deployment", "ConfigMap"} {
+		if ordered[i].kind != k {
+			t.Errorf("apply order #%d: expected %s, got %s", i, k, ordered[i].kind)
+		}
+	}
+
+	objs[1].drifted = true
+	roundtrip, err := manifestsObjectsFromValue(manifestsObjectsToValue(objs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range objs {
+		if roundtrip[i].key() != objs[i].key() || roundtrip[i].apiVersion != objs[i].apiVersion || roundtrip[i].drifted != objs[i].drifted {
+			t.Errorf("object #%d did not survive conversion to state: %s", i, roundtrip[i])
+		}
+	}
+}
+
+func TestDecodeManifestsInvalid(t *testing.T) {
+	samples := map[string]string{
+		"missing name": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  generateName: foo-\n",
+		"duplicate": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n---\n" +
+			"apiVersion: v1beta1\nkind: ConfigMap\nmetadata:\n  name: foo\n",
+		"not a manifest": "foo: bar\n",
+	}
+	for name, content := range samples {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeManifests(content); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestManifestsObjectDrifted(t *testing.T) {
+	manifest := func() map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "settings",
+				"namespace": "default",
+			},
+			"data": map[string]interface{}{
+				"size": "1000000",
+			},
+		}
+	}
+	samples := map[string]struct {
+		managedFields []metav1.ManagedFieldsEntry
+		drifted       bool
+	}{
+		"owned": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				newManagedFieldsEntry("Terraform", metav1.ManagedFieldsOperationApply, `{"f:data":{"f:size":{}}}`),
+			},
+		},
+		"changed by another field manager": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				newManagedFieldsEntry("Terraform", metav1.ManagedFieldsOperationApply, `{"f:data":{".":{}}}`),
+				newManagedFieldsEntry("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:size":{}}}`),
+			},
+			drifted: true,
+		},
+	}
+	s := &RawProviderServer{}
+	for name, sample := range samples {
+		t.Run(name, func(t *testing.T) {
+			live := &unstructured.Unstructured{Object: manifest()}
+			live.SetManagedFields(sample.managedFields)
+			drifted, err := s.manifestsObjectDrifted(live, manifest(), "Terraform")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if drifted != sample.drifted {
+				t.Fatalf("expected drifted to be %t", sample.drifted)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+)
+
+// withDefaultMetadata adds the default annotations and labels of the provider to a planned object.
+// The default keys the manifest does not set are planned with their default value, replacing the value
+// found in the prior state. Maps that are still unknown are left alone, as the defaults are added to them
+// during apply. For the maps listed in 'computed' by the user, the keys are not fixed: the map
+// is planned as unknown when the defaults would change it.
+func withDefaultMetadata(obj, manifest tftypes.Value, annotations, labels map[string]string, computed map[string]*tftypes.AttributePath) (tftypes.Value, error) {
+	if len(annotations) == 0 && len(labels) == 0 {
+		return obj, nil
+	}
+	metadataPath := tftypes.NewAttributePath().WithAttributeName("metadata")
+	defaults := map[string]map[string]string{
+		"annotations": annotations,
+		"labels":      labels,
+	}
+	return tftypes.Transform(obj, func(ap *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		steps := ap.Steps()
+		if len(steps) != 2 || !ap.WithoutLastStep().Equal(metadataPath) {
+			return v, nil
+		}
+		name, ok := steps[1].(tftypes.AttributeName)
+		if !ok || len(defaults[string(name)]) == 0 || !v.IsKnown() || !v.Type().Is(tftypes.Map{}) {
+			return v, nil
+		}
+		m := make(map[string]tftypes.Value)
+		if !v.IsNull() {
+			if err := v.As(&m); err != nil {
+				return v, err
+			}
+		}
+		configured, err := manifestMapKeys(manifest, ap)
+		if err != nil {
+			return v, err
+		}
+		_, isComputed := computed[ap.String()]
+		et := v.Type().(tftypes.Map).ElementType
+		for k, dv := range defaults[string(name)] {
+			if configured[k] {
+				continue
+			}
+			nv := tftypes.NewValue(et, dv)
+			if cv, ok := m[k]; ok && cv.Equal(nv) {
+				continue
+			}
+			if isComputed {
+				return tftypes.NewValue(v.Type(), tftypes.UnknownValue), nil
+			}
+			m[k] = nv
+		}
+		return tftypes.NewValue(v.Type(), m), nil
+	})
+}
+
+// manifestMapKeys returns the keys of the map found at path 'ap' of a manifest
+func manifestMapKeys(manifest tftypes.Value, ap *tftypes.AttributePath) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	v, restPath, err := tftypes.WalkAttributePath(manifest, ap)
+	if err != nil || len(restPath.Steps()) > 0 {
+		return keys, nil
+	}
+	mv, ok := v.(tftypes.Value)
+	if !ok || mv.IsNull() || !mv.IsKnown() {
+		return keys, nil
+	}
+	var m map[string]tftypes.Value
+	if err := mv.As(&m); err != nil {
+		return nil, err
+	}
+	for k := range m {
+		keys[k] = true
+	}
+	return keys, nil
+}
+
+// setDefaultMetadata adds the default annotations and labels of the provider to an object in unstructured form,
+// for the keys the object does not set itself
+func setDefaultMetadata(obj map[string]interface{}, annotations, labels map[string]string) {
+	if len(annotations) == 0 && len(labels) == 0 {
+		return
+	}
+	u := unstructured.Unstructured{Object: obj}
+	if len(annotations) > 0 {
+		u.SetAnnotations(util.MergeDefaults(u.GetAnnotations(), annotations))
+	}
+	if len(labels) > 0 {
+		u.SetLabels(util.MergeDefaults(u.GetLabels(), labels))
+	}
+}
+
+// setProviderMetadata adds the metadata configured at the provider level to an object in unstructured form:
+// the default annotations and labels, and the label marking the object as a member of the provider's ApplySet.
+func (s *RawProviderServer) setProviderMetadata(obj map[string]interface{}) {
+	setDefaultMetadata(obj, s.defaultAnnotations, s.defaultLabels)
+	setApplySetLabel(obj, s.applySetID)
+}
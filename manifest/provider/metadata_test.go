@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWithDefaultMetadata(t *testing.T) {
+	mapType := tftypes.Map{ElementType: tftypes.String}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"metadata": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":        tftypes.String,
+			"annotations": mapType,
+			"labels":      mapType,
+		}},
+		"spec": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"labels": mapType,
+		}},
+	}}
+	newMap := func(m map[string]string) tftypes.Value {
+		if m == nil {
+			return tftypes.NewValue(mapType, nil)
+		}
+		vals := make(map[string]tftypes.Value, len(m))
+		for k, v := range m {
+			vals[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(mapType, vals)
+	}
+	newObj := func(annotations, labels tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"metadata": tftypes.NewValue(objType.AttributeTypes["metadata"], map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "test"),
+				"annotations": annotations,
+				"labels":      labels,
+			}),
+			"spec": tftypes.NewValue(objType.AttributeTypes["spec"], map[string]tftypes.Value{
+				"labels": newMap(nil),
+			}),
+		})
+	}
+	annotations := map[string]string{"example.com/owner": "platform"}
+	labels := map[string]string{"team": "platform", "env": "production"}
+
+	labelsPath := tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("labels")
+
+	samples := map[string]struct {
+		in       tftypes.Value
+		manifest tftypes.Value
+		computed map[string]*tftypes.AttributePath
+		out      tftypes.Value
+	}{
+		"null": {
+			in:       newObj(newMap(nil), newMap(nil)),
+			manifest: newObj(newMap(nil), newMap(nil)),
+			out:      newObj(newMap(annotations), newMap(labels)),
+		},
+		"precedence": {
+			in:       newObj(newMap(nil), newMap(map[string]string{"app": "web", "team": "data"})),
+			manifest: newObj(newMap(nil), newMap(map[string]string{"app": "web", "team": "data"})),
+			out:      newObj(newMap(annotations), newMap(map[string]string{"app": "web", "team": "data", "env": "production"})),
+		},
+		"unknown": {
+			in:       newObj(tftypes.NewValue(mapType, tftypes.UnknownValue), tftypes.NewValue(mapType, tftypes.UnknownValue)),
+			manifest: newObj(newMap(nil), newMap(nil)),
+			out:      newObj(tftypes.NewValue(mapType, tftypes.UnknownValue), tftypes.NewValue(mapType, tftypes.UnknownValue)),
+		},
+		"prior values": {
+			in:       newObj(newMap(map[string]string{"webhook.example.com/injected": "true"}), newMap(map[string]string{"app": "web", "team": "data"})),
+			manifest: newObj(newMap(nil), newMap(map[string]string{"app": "web"})),
+			out: newObj(
+				newMap(map[string]string{"webhook.example.com/injected": "true", "example.com/owner": "platform"}),
+				newMap(map[string]string{"app": "web", "team": "platform", "env": "production"}),
+			),
+		},
+		"computed by the user": {
+			in:       newObj(newMap(nil), newMap(map[string]string{"app": "web", "team": "platform", "env": "staging"})),
+			manifest: newObj(newMap(nil), newMap(map[string]string{"app": "web"})),
+			computed: map[string]*tftypes.AttributePath{labelsPath.String(): labelsPath},
+			out:      newObj(newMap(annotations), tftypes.NewValue(mapType, tftypes.UnknownValue)),
+		},
+		"computed by the user and unchanged": {
+			in:       newObj(newMap(nil), newMap(map[string]string{"app": "web", "team": "platform", "env": "production"})),
+			manifest: newObj(newMap(nil), newMap(map[string]string{"app": "web"})),
+			computed: map[string]*tftypes.AttributePath{labelsPath.String(): labelsPath},
+			out:      newObj(newMap(annotations), newMap(map[string]string{"app": "web", "team": "platform", "env": "production"})),
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			out, err := withDefaultMetadata(s.in, s.manifest, annotations, labels, s.computed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !out.Equal(s.out) {
+				t.Fatalf("expected %s, got %s", s.out, out)
+			}
+		})
+	}
+}
+
+func TestSetDefaultMetadata(t *testing.T) {
+	obj := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "test",
+			"labels": map[string]interface{}{
+				"team": "data",
+			},
+		},
+	}
+	setDefaultMetadata(obj, map[string]string{"example.com/owner": "platform"}, map[string]string{"team": "platform", "env": "production"})
+	md := obj["metadata"].(map[string]interface{})
+	expectedLabels := map[string]interface{}{"team": "data", "env": "production"}
+	if !reflect.DeepEqual(md["labels"], expectedLabels) {
+		t.Fatalf("expected labels %v, got %v", expectedLabels, md["labels"])
+	}
+	expectedAnnotations := map[string]interface{}{"example.com/owner": "platform"}
+	if !reflect.DeepEqual(md["annotations"], expectedAnnotations) {
+		t.Fatalf("expected annotations %v, got %v", expectedAnnotations, md["annotations"])
+	}
+}
@@ -15,24 +15,26 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 )
 
-func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, fieldManager string, forceConflicts bool, isNamespaced bool) error {
+// dryRun performs a server-side dry-run apply of the manifest and returns the object the API server would persist
+func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, th map[string]string, fieldManager string, forceConflicts bool, isNamespaced bool) (*unstructured.Unstructured, error) {
 	c, err := s.getDynamicClient()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve Kubernetes dynamic client during apply: %v", err)
+		return nil, fmt.Errorf("failed to retrieve Kubernetes dynamic client during apply: %v", err)
 	}
 	m, err := s.getRestMapper()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve Kubernetes RESTMapper client during apply: %v", err)
+		return nil, fmt.Errorf("failed to retrieve Kubernetes RESTMapper client during apply: %v", err)
 	}
 
 	minObj := morph.UnknownToNull(obj)
-	pu, err := payload.FromTFValue(minObj, nil, tftypes.NewAttributePath())
+	pu, err := payload.FromTFValue(minObj, th, tftypes.NewAttributePath())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rqObj := mapRemoveNulls(pu.(map[string]interface{}))
@@ -44,7 +46,7 @@ func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, field
 
 	gvr, err := GVRFromUnstructured(&uo, m)
 	if err != nil {
-		return fmt.Errorf("failed to determine resource GVR: %s", err)
+		return nil, fmt.Errorf("failed to determine resource GVR: %s", err)
 	}
 
 	var rs dynamic.ResourceInterface
@@ -56,23 +58,96 @@ func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, field
 
 	jsonManifest, err := uo.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("failed to marshall resource %q to JSON: %v", rnn, err)
+		return nil, fmt.Errorf("failed to marshall resource %q to JSON: %v", rnn, err)
 	}
-	_, err = rs.Patch(ctx, rname, types.ApplyPatchType, jsonManifest,
+	return rs.Patch(ctx, rname, types.ApplyPatchType, jsonManifest,
 		metav1.PatchOptions{
 			FieldManager: fieldManager,
 			Force:        &forceConflicts,
 			DryRun:       []string{"All"},
 		},
 	)
+}
+
+// dryRunAllocatedFields lists the fields of built-in kinds the API server allocates a value for on each request,
+// so that the value returned by a dry-run is not the one the resource is created with
+var dryRunAllocatedFields = map[schema.GroupKind][]string{
+	{Group: "", Kind: "Service"}: {"spec.clusterIP", "spec.clusterIPs", "spec.healthCheckNodePort", "spec.ports.nodePort"},
+	// the controller-uid selector of a Job is derived from the UID generated for the object
+	{Group: "batch", Kind: "Job"}: {"spec.selector.matchLabels", "spec.template.metadata.labels"},
+}
 
-	return err
+// dryRunAllocatedFieldPaths returns the paths of the fields of a built-in kind allocated by the API server.
+// The paths leave out list indices, and apply to all the elements of the lists they traverse.
+func dryRunAllocatedFieldPaths(gvk schema.GroupVersionKind) []*tftypes.AttributePath {
+	var paths []*tftypes.AttributePath
+	for _, f := range dryRunAllocatedFields[gvk.GroupKind()] {
+		paths = append(paths, fieldPath(f))
+	}
+	return paths
+}
+
+// withDryRunValues replaces the unknown values of a planned object with the values of the objects returned
+// by server-side dry-runs. Only the values all the dry-runs agree on are used, as values generated on each request,
+// like random names injected by admission webhooks, differ from the ones the resource is created with.
+// The values under the paths in skip are left unknown.
+func withDryRunValues(planned tftypes.Value, dryRuns []tftypes.Value, skip []*tftypes.AttributePath) (tftypes.Value, error) {
+	if len(dryRuns) == 0 {
+		return planned, nil
+	}
+	return tftypes.Transform(planned, func(ap *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() || len(ap.Steps()) == 0 {
+			return v, nil
+		}
+		for _, p := range skip {
+			if hasPathPrefix(ap, p) || hasPathPrefix(withoutElementKeys(ap), p) {
+				return v, nil
+			}
+		}
+		var nv tftypes.Value
+		for i, dryRun := range dryRuns {
+			dv, restPath, err := tftypes.WalkAttributePath(dryRun, ap)
+			if err != nil || len(restPath.Steps()) > 0 {
+				return v, nil
+			}
+			drv, ok := dv.(tftypes.Value)
+			if !ok || !drv.Type().Equal(v.Type()) || (i > 0 && !drv.Equal(nv)) {
+				return v, nil
+			}
+			nv = drv
+		}
+		return nv, nil
+	})
+}
+
+// hasPathPrefix reports whether the attribute path p starts with the steps of prefix
+func hasPathPrefix(p, prefix *tftypes.AttributePath) bool {
+	ps := p.Steps()
+	if len(ps) < len(prefix.Steps()) {
+		return false
+	}
+	return tftypes.NewAttributePathWithSteps(ps[:len(prefix.Steps())]).Equal(prefix)
+}
+
+// withoutElementKeys returns the attribute path p without its list, set and map element steps
+func withoutElementKeys(p *tftypes.AttributePath) *tftypes.AttributePath {
+	np := tftypes.NewAttributePath()
+	for _, s := range p.Steps() {
+		if n, ok := s.(tftypes.AttributeName); ok {
+			np = np.WithAttributeName(string(n))
+		}
+	}
+	return np
 }
 
 const defaultFieldManagerName = "Terraform"
 
 func (s *RawProviderServer) getFieldManagerConfig(v map[string]tftypes.Value) (string, bool, error) {
 	fieldManagerName := defaultFieldManagerName
+	if subresource, err := getSubresource(v); err == nil && subresource != "" {
+		// subresources are applied with their own field manager, so not to take ownership of the resource
+		fieldManagerName = defaultFieldManagerName + "-" + subresource
+	}
 	forceConflicts := false
 	if !v["field_manager"].IsNull() && v["field_manager"].IsKnown() {
 		var fieldManagerBlock []tftypes.Value
@@ -103,6 +178,45 @@ func (s *RawProviderServer) getFieldManagerConfig(v map[string]tftypes.Value) (s
 	return fieldManagerName, forceConflicts, nil
 }
 
+// getMigrateFromConfig returns the field managers whose fields are migrated to the field manager of the resource,
+// as set in the 'migrate_from' attribute of the 'field_manager' block
+func getMigrateFromConfig(v map[string]tftypes.Value) ([]string, error) {
+	if v["field_manager"].IsNull() || !v["field_manager"].IsKnown() {
+		return nil, nil
+	}
+	var fieldManagerBlock []tftypes.Value
+	if err := v["field_manager"].As(&fieldManagerBlock); err != nil {
+		return nil, err
+	}
+	if len(fieldManagerBlock) == 0 {
+		return nil, nil
+	}
+	var fieldManagerObj map[string]tftypes.Value
+	if err := fieldManagerBlock[0].As(&fieldManagerObj); err != nil {
+		return nil, err
+	}
+	mf, ok := fieldManagerObj["migrate_from"]
+	if !ok || mf.IsNull() {
+		return nil, nil
+	}
+	if !mf.IsFullyKnown() {
+		return nil, fmt.Errorf("the value of 'migrate_from' must be known")
+	}
+	var names []tftypes.Value
+	if err := mf.As(&names); err != nil {
+		return nil, err
+	}
+	managers := make([]string, 0, len(names))
+	for _, n := range names {
+		var name string
+		if err := n.As(&name); err != nil {
+			return nil, err
+		}
+		managers = append(managers, name)
+	}
+	return managers, nil
+}
+
 func isImportedFlagFromPrivate(p []byte) (f bool, d []*tfprotov5.Diagnostic) {
 	if len(p) == 0 {
 		return
@@ -126,8 +240,33 @@ func isImportedFlagFromPrivate(p []byte) (f bool, d []*tfprotov5.Diagnostic) {
 	return
 }
 
+// deferPlan requests deferral of a resource change, planning the 'object' attribute as unknown
+func deferPlan(resp *tfprotov5.PlanResourceChangeResponse, proposedState tftypes.Value, proposedVal map[string]tftypes.Value, reason tfprotov5.DeferredReason) error {
+	proposedVal["object"] = tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue)
+	newPlannedState := tftypes.NewValue(proposedState.Type(), proposedVal)
+	ps, err := tfprotov5.NewDynamicValue(newPlannedState.Type(), newPlannedState)
+	if err != nil {
+		return err
+	}
+	resp.PlannedState = &ps
+	resp.Deferred = &tfprotov5.Deferred{
+		Reason: reason,
+	}
+	return nil
+}
+
 // PlanResourceChange function
 func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	if req.TypeName == manifestsResourceType {
+		return s.planManifests(req)
+	}
+	if req.TypeName == applySetResourceType {
+		return s.planApplySet(req)
+	}
+	if req.TypeName == manifestPatchResourceType {
+		return s.planManifestPatch(req)
+	}
+
 	resp := &tfprotov5.PlanResourceChangeResponse{}
 
 	rt, err := GetResourceType(req.TypeName)
@@ -162,26 +301,79 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		return resp, nil
 	}
 
-	canDeferr := req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed
+	// Decode prior resource state
+	priorState, err := req.PriorState.Unmarshal(rt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal prior resource state",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	s.logger.Trace("[PlanResourceChange]", "[PriorState]", dump(priorState))
 
-	if canDeferr && s.clientConfigUnknown {
-		// if client supports it, request deferral when client configuration not fully known
+	priorVal := make(map[string]tftypes.Value)
+	err = priorState.As(&priorVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract prior resource state from tftypes.Value",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
+	// The manifest can be configured either in HCL or in YAML. YAML manifests are decoded
+	// without a type until the resource type is known, which is enough to identify the resource.
+	ppMan, err := manifestValue(proposedVal, nil, nil)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Failed to decode manifest",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName(manifestAttribute(proposedVal)),
+		})
+		return resp, nil
+	}
+	// the manifest can only switch between HCL and YAML without replacing the resource
+	// if the identity of the resource is compared here, rather than by Terraform
+	usesYAML := manifestAttribute(proposedVal) == "manifest_yaml" || manifestAttribute(priorVal) == "manifest_yaml"
+
+	if !proposedState.IsNull() && !ppMan.IsKnown() {
+		// the YAML manifest is not known yet, and neither is the resource it describes
 		proposedVal["object"] = tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue)
-		newPlannedState := tftypes.NewValue(proposedState.Type(), proposedVal)
-		ps, err := tfprotov5.NewDynamicValue(newPlannedState.Type(), newPlannedState)
+		propStateVal := tftypes.NewValue(proposedState.Type(), proposedVal)
+		plannedState, err := tfprotov5.NewDynamicValue(propStateVal.Type(), propStateVal)
 		if err != nil {
 			return resp, err
 		}
-		resp.PlannedState = &ps
-		resp.Deferred = &tfprotov5.Deferred{
-			Reason: tfprotov5.DeferredReasonProviderConfigUnknown,
-		}
+		resp.PlannedState = &plannedState
 		return resp, nil
 	}
 
+	canDeferr := req.ClientCapabilities != nil && req.ClientCapabilities.DeferralAllowed
+
+	// Built-in kinds can be planned against the OpenAPI spec bundled with the provider,
+	// when configured to do so. This avoids contacting the API server until apply.
+	var offlineGVK schema.GroupVersionKind
+	var offlineNS, isOffline bool
+	if ppMan.IsKnown() && !ppMan.IsNull() {
+		if gvk, err := gvkFromTftypesObject(&ppMan); err == nil {
+			offlineNS, isOffline = s.lookUpOfflineGVK(gvk)
+			offlineGVK = gvk
+		}
+	}
+
+	if canDeferr && s.clientConfigUnknown && !isOffline {
+		// if client supports it, request deferral when client configuration not fully known
+		err := deferPlan(resp, proposedState, proposedVal, tfprotov5.DeferredReasonProviderConfigUnknown)
+		return resp, err
+	}
+
 	isImported, d := isImportedFlagFromPrivate(req.PriorPrivate)
 	resp.Diagnostics = append(resp.Diagnostics, d...)
-	if !isImported {
+	if !isImported && !usesYAML {
 		resp.RequiresReplace = append(resp.RequiresReplace,
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("apiVersion"),
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("kind"),
@@ -191,19 +383,46 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		resp.PlannedPrivate = req.PriorPrivate
 	}
 
+	subresource, err := getSubresource(proposedVal)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid subresource",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("subresource"),
+		})
+		return resp, nil
+	}
+	resp.RequiresReplace = append(resp.RequiresReplace, tftypes.NewAttributePath().WithAttributeName("subresource"))
+
 	execDiag := s.canExecute()
 	if len(execDiag) > 0 {
 		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
 		return resp, nil
 	}
 
-	// test if credentials are valid - we're going to need them further down
-	resp.Diagnostics = append(resp.Diagnostics, s.checkValidCredentials(ctx)...)
-	if len(resp.Diagnostics) > 0 {
-		return resp, nil
+	if !isOffline {
+		// test if credentials are valid - we're going to need them further down
+		cd := s.checkValidCredentials(ctx)
+		if len(cd) > 0 && canDeferr && s.apiServerUnreachable {
+			// the cluster is likely being created in the same apply - request deferral
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityWarning,
+				Summary:  "Kubernetes API server is not reachable, the resource change will be deferred",
+				Detail:   cd[0].Detail,
+			})
+			err := deferPlan(resp, proposedState, proposedVal, tfprotov5.DeferredReasonProviderConfigUnknown)
+			return resp, err
+		}
+		resp.Diagnostics = append(resp.Diagnostics, cd...)
+		if len(resp.Diagnostics) > 0 {
+			return resp, nil
+		}
 	}
 
 	computedFields := make(map[string]*tftypes.AttributePath)
+	// the computed fields listed by the user, as opposed to the default ones
+	userComputedFields := computedFields
 	var atp *tftypes.AttributePath
 	cfVal, ok := proposedVal["computed_fields"]
 	if ok && !cfVal.IsNull() && cfVal.IsKnown() {
@@ -232,32 +451,9 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		// When not specified by the user, 'metadata.annotations' and 'metadata.labels' are configured as default
 		atp = tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("annotations")
 		computedFields[atp.String()] = atp
-
 		atp = tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("labels")
 		computedFields[atp.String()] = atp
-	}
-
-	// Decode prior resource state
-	priorState, err := req.PriorState.Unmarshal(rt)
-	if err != nil {
-		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
-			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Failed to unmarshal prior resource state",
-			Detail:   err.Error(),
-		})
-		return resp, nil
-	}
-	s.logger.Trace("[PlanResourceChange]", "[PriorState]", dump(priorState))
-
-	priorVal := make(map[string]tftypes.Value)
-	err = priorState.As(&priorVal)
-	if err != nil {
-		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
-			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Failed to extract prior resource state from tftypes.Value",
-			Detail:   err.Error(),
-		})
-		return resp, nil
+		userComputedFields = nil
 	}
 
 	if proposedState.IsNull() {
@@ -274,68 +470,81 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		return resp, nil
 	}
 
-	ppMan, ok := proposedVal["manifest"]
-	if !ok {
-		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
-			Severity:  tfprotov5.DiagnosticSeverityError,
-			Summary:   "Invalid proposed state during planning",
-			Detail:    "Missing 'manifest' attribute",
-			Attribute: tftypes.NewAttributePath().WithAttributeName("manifest"),
-		})
-		return resp, nil
-	}
-
-	rm, err := s.getRestMapper()
-	if err != nil {
-		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
-			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Failed to create K8s RESTMapper client",
-			Detail:   err.Error(),
-		})
-		return resp, nil
-	}
-	gvk, err := GVKFromTftypesObject(&ppMan, rm)
-	if err != nil {
-		rd := &tfprotov5.Diagnostic{
-			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "API did not recognize GroupVersionKind from manifest (CRD may not be installed)",
-			Detail:   err.Error(),
+	var gvk schema.GroupVersionKind
+	var ns bool
+	if isOffline {
+		gvk, ns = offlineGVK, offlineNS
+		vdiags := validateResourceNamespace(&ppMan, gvk, ns)
+		if len(vdiags) > 0 {
+			resp.Diagnostics = append(resp.Diagnostics, vdiags...)
+			return resp, nil
 		}
-		resp.Diagnostics = append(resp.Diagnostics, rd)
-		if canDeferr && meta.IsNoMatchError(err) {
-			// request deferral when client configuration not fully known
-			resp.Deferred = &tfprotov5.Deferred{
-				Reason: tfprotov5.DeferredReasonResourceConfigUnknown,
+	} else {
+		rm, err := s.getRestMapper()
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to create K8s RESTMapper client",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		gvk, err = GVKFromTftypesObject(&ppMan, rm)
+		if err != nil {
+			rd := &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "API did not recognize GroupVersionKind from manifest (CRD may not be installed)",
+				Detail:   err.Error(),
+			}
+			resp.Diagnostics = append(resp.Diagnostics, rd)
+			if canDeferr && meta.IsNoMatchError(err) {
+				// the CRD is likely being installed in the same apply - request deferral
+				rd.Severity = tfprotov5.DiagnosticSeverityWarning
+				err := deferPlan(resp, proposedState, proposedVal, tfprotov5.DeferredReasonAbsentPrereq)
+				return resp, err
 			}
-			rd.Severity = tfprotov5.DiagnosticSeverityWarning
+			if canDeferr && isAPIServerUnreachable(err) {
+				rd.Severity = tfprotov5.DiagnosticSeverityWarning
+				err := deferPlan(resp, proposedState, proposedVal, tfprotov5.DeferredReasonProviderConfigUnknown)
+				return resp, err
+			}
+			return resp, nil
 		}
-		return resp, nil
-	}
 
-	vdiags := s.validateResourceOnline(&ppMan)
-	if len(vdiags) > 0 {
-		resp.Diagnostics = append(resp.Diagnostics, vdiags...)
-		return resp, nil
-	}
+		vdiags := s.validateResourceOnline(&ppMan)
+		if len(vdiags) > 0 {
+			resp.Diagnostics = append(resp.Diagnostics, vdiags...)
+			return resp, nil
+		}
 
-	ns, err := IsResourceNamespaced(gvk, rm)
-	if err != nil {
-		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
-			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Failed to discover scope of resource",
-			Detail:   err.Error(),
-		})
-		return resp, nil
+		ns, err = IsResourceNamespaced(gvk, rm)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to discover scope of resource",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
 	}
-	if ns && !isImported {
+	if ns && !isImported && !usesYAML {
 		resp.RequiresReplace = append(resp.RequiresReplace,
 			tftypes.NewAttributePath().WithAttributeName("manifest").WithAttributeName("metadata").WithAttributeName("namespace"),
 		)
 	}
 
 	// Request a complete type for the resource from the OpenAPI spec
-	objectType, hints, err := s.TFTypeFromOpenAPI(ctx, gvk, false)
+	objectType, hints, err := s.subresourceType(ctx, gvk, subresource)
 	if err != nil {
+		if canDeferr && isAPIServerUnreachable(err) {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityWarning,
+				Summary:  "Kubernetes API server is not reachable, the resource change will be deferred",
+				Detail:   err.Error(),
+			})
+			err := deferPlan(resp, proposedState, proposedVal, tfprotov5.DeferredReasonProviderConfigUnknown)
+			return resp, err
+		}
 		return resp, fmt.Errorf("failed to determine resource type ID: %s", err)
 	}
 
@@ -360,7 +569,7 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 			return resp, nil
 		}
 
-		err = s.dryRun(ctx, ppMan, fieldManagerName, forceConflicts, ns)
+		_, err = s.dryRun(ctx, ppMan, nil, fieldManagerName, forceConflicts, ns)
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
@@ -371,11 +580,26 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		}
 
 		resp.RequiresReplace = []*tftypes.AttributePath{
-			tftypes.NewAttributePath().WithAttributeName("manifest"),
+			tftypes.NewAttributePath().WithAttributeName(manifestAttribute(proposedVal)),
 			tftypes.NewAttributePath().WithAttributeName("object"),
 		}
 	}
 
+	// now that the resource type is known, YAML manifests can be decoded with their values typed
+	// the same way the API server would interpret them
+	if manifestAttribute(proposedVal) == "manifest_yaml" {
+		ppMan, err = manifestValue(proposedVal, objectType, hints)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Failed to decode manifest",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("manifest_yaml"),
+			})
+			return resp, nil
+		}
+	}
+
 	so := objectType.(tftypes.Object)
 	s.logger.Debug("[PlanUpdateResource]", "OAPI type", dump(so))
 
@@ -436,8 +660,7 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 			})
 			return resp, nil
 		}
-		priorMan, ok := priorVal["manifest"]
-		if !ok {
+		if _, ok := priorVal["manifest"]; !ok {
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 				Severity:  tfprotov5.DiagnosticSeverityError,
 				Summary:   "Invalid prior state during planning",
@@ -446,6 +669,25 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 			})
 			return resp, nil
 		}
+		priorMan, err := manifestValue(priorVal, objectType, hints)
+		if err != nil {
+			// the prior manifest may describe a resource of a different type
+			priorMan, err = manifestValue(priorVal, nil, nil)
+		}
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid prior state during planning",
+				Detail:    fmt.Sprintf("Failed to decode prior manifest: %s", err),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("manifest_yaml"),
+			})
+			return resp, nil
+		}
+		if usesYAML && !isImported && manifestIdentityChanged(priorMan, ppMan, ns) {
+			resp.RequiresReplace = append(resp.RequiresReplace,
+				tftypes.NewAttributePath().WithAttributeName(manifestAttribute(proposedVal)),
+			)
+		}
 		updatedObj, err := tftypes.Transform(completePropMan, func(ap *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
 			_, isComputed := computedFields[ap.String()]
 			if v.IsKnown() { // this is a value from current configuration - include it in the plan
@@ -512,7 +754,113 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 		}
 
 		proposedVal["object"] = updatedObj
+
+		// changes to immutable fields cannot be applied in-place, so they require replacing the resource
+		if subresource == "" {
+			immutable := builtinImmutableFieldPaths(gvk)
+			if !isOffline {
+				fieldManagerName, _, _ := s.getFieldManagerConfig(proposedVal)
+				immutable = append(immutable, s.clusterImmutableFieldPaths(ctx, gvk, morphedManifest, hints, fieldManagerName, ns, !priorMan.Equal(ppMan))...)
+			}
+			resp.RequiresReplace = append(resp.RequiresReplace, changedFields(priorObj, updatedObj, immutable)...)
+		}
+	}
+
+	// the values the API server sets on the object when it is applied can be planned from a dry-run,
+	// as long as the manifest is known in full and the object is not a subresource
+	pd, ok := proposedVal["plan_with_dry_run"]
+	if ok && !pd.IsNull() && pd.IsKnown() && !isOffline && subresource == "" &&
+		morphedManifest.IsFullyKnown() && !proposedVal["object"].IsFullyKnown() {
+		var planWithDryRun bool
+		pd.As(&planWithDryRun)
+		if planWithDryRun {
+			fieldManagerName, forceConflicts, err := s.getFieldManagerConfig(proposedVal)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Could not extract field_manager config",
+					Detail:   err.Error(),
+				})
+				return resp, nil
+			}
+			// two dry-runs are compared, to tell the values the API server generates on each request apart
+			var dryRunObjs []tftypes.Value
+			for i := 0; i < 2; i++ {
+				dro, err := s.dryRun(ctx, morphedManifest, hints, fieldManagerName, forceConflicts, ns)
+				if err != nil {
+					resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "Dry-run failed while planning the resource",
+						Detail:   fmt.Sprintf("A dry-run apply was performed for this resource but was unsuccessful: %v", err),
+					})
+					return resp, nil
+				}
+				dryRunObj, err := payload.ToTFValue(RemoveServerSideFields(dro.Object), objectType, hints, tftypes.NewAttributePath())
+				if err == nil {
+					dryRunObj, err = morph.DeepUnknown(objectType, dryRunObj, tftypes.NewAttributePath())
+				}
+				if err != nil {
+					resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+						Severity:  tfprotov5.DiagnosticSeverityError,
+						Summary:   "Failed to convert the dry-run result to the resource type",
+						Detail:    err.Error(),
+						Attribute: tftypes.NewAttributePath().WithAttributeName("object"),
+					})
+					return resp, nil
+				}
+				dryRunObjs = append(dryRunObjs, dryRunObj)
+			}
+			skip := dryRunAllocatedFieldPaths(gvk)
+			for _, p := range computedFields {
+				skip = append(skip, p)
+			}
+			plannedObj, err := withDryRunValues(proposedVal["object"], dryRunObjs, skip)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "Failed to plan the resource from the dry-run result",
+					Detail:    err.Error(),
+					Attribute: tftypes.NewAttributePath().WithAttributeName("object"),
+				})
+				return resp, nil
+			}
+			proposedVal["object"] = plannedObj
+		}
+	}
+
+	// the default annotations and labels of the provider are added to the object,
+	// unless only a subresource of the object is managed
+	if subresource == "" {
+		defaultedObj, err := withDefaultMetadata(proposedVal["object"], morphedManifest, s.defaultAnnotations, s.defaultLabels, userComputedFields)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Failed to add default metadata to planned object",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("object"),
+			})
+			return resp, nil
+		}
+		proposedVal["object"] = defaultedObj
+	}
+
+	// objects are labelled as members of the provider's ApplySet, if any,
+	// unless only a subresource of the object is managed
+	applySetID := s.applySetID
+	if subresource != "" {
+		applySetID = ""
+	}
+	labelledObj, err := withApplySetLabel(proposedVal["object"], applySetID)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Failed to add ApplySet label to planned object",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("object"),
+		})
+		return resp, nil
 	}
+	proposedVal["object"] = labelledObj
 
 	propStateVal := tftypes.NewValue(proposedState.Type(), proposedVal)
 	s.logger.Trace("[PlanResourceChange]", "new planned state", dump(propStateVal))
@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestWithDryRunValues(t *testing.T) {
+	portType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"port":     tftypes.Number,
+		"protocol": tftypes.String,
+		"nodePort": tftypes.Number,
+	}}
+	metadataType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":   tftypes.String,
+		"labels": tftypes.Map{ElementType: tftypes.String},
+	}}
+	specType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"type":      tftypes.String,
+		"clusterIP": tftypes.String,
+		"ports":     tftypes.List{ElementType: portType},
+	}}
+	serviceType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"metadata": metadataType,
+		"spec":     specType,
+	}}
+	newService := func(labels tftypes.Value, svcType, clusterIP, protocol, nodePort interface{}) tftypes.Value {
+		return tftypes.NewValue(serviceType, map[string]tftypes.Value{
+			"metadata": tftypes.NewValue(metadataType, map[string]tftypes.Value{
+				"name":   tftypes.NewValue(tftypes.String, "test"),
+				"labels": labels,
+			}),
+			"spec": tftypes.NewValue(specType, map[string]tftypes.Value{
+				"type":      tftypes.NewValue(tftypes.String, svcType),
+				"clusterIP": tftypes.NewValue(tftypes.String, clusterIP),
+				"ports": tftypes.NewValue(tftypes.List{ElementType: portType}, []tftypes.Value{
+					tftypes.NewValue(portType, map[string]tftypes.Value{
+						"port":     tftypes.NewValue(tftypes.Number, 80),
+						"protocol": tftypes.NewValue(tftypes.String, protocol),
+						"nodePort": tftypes.NewValue(tftypes.Number, nodePort),
+					}),
+				}),
+			}),
+		})
+	}
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+	planned := newService(tftypes.NewValue(labelsType, tftypes.UnknownValue),
+		tftypes.UnknownValue, tftypes.UnknownValue, tftypes.UnknownValue, tftypes.UnknownValue)
+	dryRun := newService(tftypes.NewValue(labelsType, map[string]tftypes.Value{
+		"injected": tftypes.NewValue(tftypes.String, "true"),
+	}), "NodePort", "10.0.0.12", "TCP", 31234)
+
+	skip := dryRunAllocatedFieldPaths(schema.GroupVersionKind{Version: "v1", Kind: "Service"})
+	skip = append(skip, tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("labels"))
+	out, err := withDryRunValues(planned, []tftypes.Value{dryRun, dryRun}, skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := newService(tftypes.NewValue(labelsType, tftypes.UnknownValue),
+		"NodePort", tftypes.UnknownValue, "TCP", tftypes.UnknownValue)
+	if !out.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, out)
+	}
+
+	// values missing from the dry-run result are left unknown
+	out, err = withDryRunValues(planned, []tftypes.Value{tftypes.NewValue(serviceType, tftypes.UnknownValue)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Equal(planned) {
+		t.Fatalf("expected %s, got %s", planned, out)
+	}
+
+	// values which differ between dry-runs are generated on each request, and are left unknown
+	otherDryRun := newService(tftypes.NewValue(labelsType, map[string]tftypes.Value{
+		"injected": tftypes.NewValue(tftypes.String, "true"),
+	}), "NodePort", "10.0.0.12", "UDP", 31234)
+	out, err = withDryRunValues(planned, []tftypes.Value{dryRun, otherDryRun}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = newService(tftypes.NewValue(labelsType, map[string]tftypes.Value{
+		"injected": tftypes.NewValue(tftypes.String, "true"),
+	}), "NodePort", "10.0.0.12", tftypes.UnknownValue, 31234)
+	if !out.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestWithDryRunValuesJob(t *testing.T) {
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+	selectorType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"matchLabels": labelsType,
+	}}
+	templateType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"metadata": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"labels": labelsType,
+		}},
+	}}
+	specType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"backoffLimit": tftypes.Number,
+		"selector":     selectorType,
+		"template":     templateType,
+	}}
+	jobType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"spec": specType,
+	}}
+	newJob := func(backoffLimit interface{}, labels tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(jobType, map[string]tftypes.Value{
+			"spec": tftypes.NewValue(specType, map[string]tftypes.Value{
+				"backoffLimit": tftypes.NewValue(tftypes.Number, backoffLimit),
+				"selector": tftypes.NewValue(selectorType, map[string]tftypes.Value{
+					"matchLabels": labels,
+				}),
+				"template": tftypes.NewValue(templateType, map[string]tftypes.Value{
+					"metadata": tftypes.NewValue(templateType.AttributeTypes["metadata"], map[string]tftypes.Value{
+						"labels": labels,
+					}),
+				}),
+			}),
+		})
+	}
+	unknownLabels := tftypes.NewValue(labelsType, tftypes.UnknownValue)
+	planned := newJob(tftypes.UnknownValue, unknownLabels)
+	dryRun := newJob(6, tftypes.NewValue(labelsType, map[string]tftypes.Value{
+		"batch.kubernetes.io/controller-uid": tftypes.NewValue(tftypes.String, "9c4b4a2e-5f8e-4a5b-9a57-0a4c38fd1f4e"),
+	}))
+
+	// the selector is left unknown even when the dry-runs happen to agree on it
+	skip := dryRunAllocatedFieldPaths(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"})
+	out, err := withDryRunValues(planned, []tftypes.Value{dryRun, dryRun}, skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := newJob(6, unknownLabels)
+	if !out.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, out)
+	}
+}
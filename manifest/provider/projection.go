@@ -0,0 +1,133 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+)
+
+// metadataFields are the fields of the objects returned when listing only the metadata of a resource
+var metadataFields = []string{"apiVersion", "kind", "metadata"}
+
+// projection is a tree of the fields to keep in an object.
+// A field without children is kept whole.
+type projection map[string]projection
+
+// newProjection builds a projection from a list of dot separated field paths, such as "metadata.name"
+func newProjection(paths []string) (projection, error) {
+	p := projection{}
+	for _, path := range paths {
+		if strings.ContainsAny(path, "[]") {
+			return nil, fmt.Errorf("invalid field path %q: selecting elements of lists is not supported", path)
+		}
+		keys := strings.Split(path, ".")
+		n := p
+		for i, k := range keys {
+			if k == "" {
+				return nil, fmt.Errorf("invalid field path %q", path)
+			}
+			c, ok := n[k]
+			if ok && c == nil {
+				// a parent of this field is already kept whole
+				break
+			}
+			if i == len(keys)-1 {
+				n[k] = nil
+				break
+			}
+			if !ok {
+				c = projection{}
+				n[k] = c
+			}
+			n = c
+		}
+	}
+	return p, nil
+}
+
+// Type returns the type of the objects of type 't' once projected
+func (p projection) Type(t tftypes.Type, at *tftypes.AttributePath) (tftypes.Type, error) {
+	switch {
+	case t.Is(tftypes.Object{}):
+		attrs := t.(tftypes.Object).AttributeTypes
+		pt := tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}
+		for k, c := range p {
+			ct, ok := attrs[k]
+			if !ok {
+				return nil, at.WithAttributeName(k).NewErrorf("[%s] field %q does not exist", at.WithAttributeName(k), k)
+			}
+			if c != nil {
+				var err error
+				ct, err = c.Type(ct, at.WithAttributeName(k))
+				if err != nil {
+					return nil, err
+				}
+			}
+			pt.AttributeTypes[k] = ct
+		}
+		return pt, nil
+	case t.Is(tftypes.Map{}), t.Is(tftypes.DynamicPseudoType):
+		// the keys of maps, and the fields of objects without a schema, are only known from the objects themselves
+		return t, nil
+	}
+	return nil, at.NewErrorf("[%s] cannot select fields within a value of type %s", at, t)
+}
+
+// Object returns the fields of 'in' kept by the projection
+func (p projection) Object(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(p))
+	for k, c := range p {
+		v, ok := in[k]
+		if !ok {
+			continue
+		}
+		if m, isMap := v.(map[string]interface{}); isMap && c != nil {
+			v = c.Object(m)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// lister lists the objects of a resource
+type lister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error)
+}
+
+// metadataLister lists only the metadata of the objects of a resource, as PartialObjectMetadata
+type metadataLister struct {
+	resource metadata.ResourceInterface
+	gvk      schema.GroupVersionKind
+}
+
+func (l metadataLister) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	res, err := l.resource.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &unstructured.UnstructuredList{}
+	out.SetResourceVersion(res.GetResourceVersion())
+	out.SetContinue(res.GetContinue())
+	out.SetRemainingItemCount(res.GetRemainingItemCount())
+	for i := range res.Items {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&res.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		item := unstructured.Unstructured{Object: obj}
+		// the items are typed as PartialObjectMetadata, rather than as the listed resource
+		item.SetGroupVersionKind(l.gvk)
+		out.Items = append(out.Items, item)
+	}
+	return out, nil
+}
@@ -0,0 +1,173 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+)
+
+func TestNewProjection(t *testing.T) {
+	samples := map[string]struct {
+		paths []string
+		out   projection
+		valid bool
+	}{
+		"fields": {
+			paths: []string{"metadata.name", "metadata.namespace", "status.phase"},
+			out: projection{
+				"metadata": projection{"name": nil, "namespace": nil},
+				"status":   projection{"phase": nil},
+			},
+			valid: true,
+		},
+		"whole parent": {
+			paths: []string{"metadata.name", "metadata", "metadata.labels"},
+			out:   projection{"metadata": nil},
+			valid: true,
+		},
+		"list element": {
+			paths: []string{"spec.containers[0].image"},
+		},
+		"empty key": {
+			paths: []string{"metadata..name"},
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			p, err := newProjection(s.paths)
+			if (err == nil) != s.valid {
+				t.Fatalf("unexpected result: %v", err)
+			}
+			if s.valid && !reflect.DeepEqual(p, s.out) {
+				t.Fatalf("expected %#v, got %#v", s.out, p)
+			}
+		})
+	}
+}
+
+func TestProjection(t *testing.T) {
+	podType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"apiVersion": tftypes.String,
+		"kind":       tftypes.String,
+		"metadata": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":   tftypes.String,
+			"labels": tftypes.Map{ElementType: tftypes.String},
+		}},
+		"spec": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"containers": tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"image": tftypes.String,
+			}}},
+		}},
+		"status": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"phase": tftypes.String,
+		}},
+	}}
+	p, err := newProjection([]string{"metadata.name", "metadata.labels.app", "status.phase"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pt, err := p.Type(podType, tftypes.NewAttributePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"metadata": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":   tftypes.String,
+			"labels": tftypes.Map{ElementType: tftypes.String},
+		}},
+		"status": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"phase": tftypes.String,
+		}},
+	}}
+	if !pt.Equal(expectedType) {
+		t.Fatalf("expected %s, got %s", expectedType, pt)
+	}
+
+	pod := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "test",
+			"labels": map[string]interface{}{
+				"app":  "web",
+				"tier": "frontend",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"image": "nginx"},
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "test",
+			"labels": map[string]interface{}{
+				"app": "web",
+			},
+		},
+	}
+	if out := p.Object(pod); !reflect.DeepEqual(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+
+	for _, paths := range [][]string{{"status.reason"}, {"spec.containers.image"}} {
+		p, err := newProjection(paths)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Type(podType, tftypes.NewAttributePath()); err == nil {
+			t.Fatalf("expected error for %v", paths)
+		}
+	}
+}
+
+// metadataResource serves a fixed list of PartialObjectMetadata
+type metadataResource struct {
+	metadata.ResourceInterface
+	list *metav1.PartialObjectMetadataList
+}
+
+func (r *metadataResource) List(ctx context.Context, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error) {
+	return r.list, nil
+}
+
+func TestMetadataLister(t *testing.T) {
+	list := &metav1.PartialObjectMetadataList{
+		ListMeta: metav1.ListMeta{ResourceVersion: "42", Continue: "next"},
+		Items: []metav1.PartialObjectMetadata{
+			{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "PartialObjectMetadata"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			},
+		},
+	}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	l := metadataLister{resource: &metadataResource{list: list}, gvk: gvk}
+	res, err := l.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetResourceVersion() != "42" || res.GetContinue() != "next" {
+		t.Fatalf("unexpected list metadata: %v", res.Object)
+	}
+	if len(res.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(res.Items))
+	}
+	item := res.Items[0]
+	if item.GroupVersionKind() != gvk {
+		t.Fatalf("unexpected kind: %s", item.GroupVersionKind())
+	}
+	if item.GetName() != "test" || item.GetLabels()["app"] != "web" {
+		t.Fatalf("unexpected metadata: %v", item.Object)
+	}
+}
@@ -128,61 +128,68 @@ func GetProviderResourceSchema() map[string]*tfprotov5.Schema {
 									DescriptionKind: 0,
 									Deprecated:      false,
 								},
+								{
+									Name:            "migrate_from",
+									Type:            tftypes.List{ElementType: tftypes.String},
+									Required:        false,
+									Optional:        true,
+									Computed:        false,
+									Sensitive:       false,
+									Description:     "The names of field managers, such as `kubectl-client-side-apply`, whose fields set with client-side apply or updates are transferred to this field manager on the first apply after the resource is imported, for the fields present in the manifest.",
+									DescriptionKind: 0,
+									Deprecated:      false,
+								},
 							},
 						},
 					},
 					{
-						TypeName: "wait",
+						TypeName: "delete_options",
 						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
 						MinItems: 0,
 						MaxItems: 1,
 						Block: &tfprotov5.SchemaBlock{
-							Description: "Configure waiter options.",
-							BlockTypes: []*tfprotov5.SchemaNestedBlock{
-								{
-									TypeName: "condition",
-									Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
-									MinItems: 0,
-									Block: &tfprotov5.SchemaBlock{
-										Attributes: []*tfprotov5.SchemaAttribute{
-											{
-												Name:        "status",
-												Type:        tftypes.String,
-												Optional:    true,
-												Description: "The condition status.",
-											}, {
-												Name:        "type",
-												Type:        tftypes.String,
-												Optional:    true,
-												Description: "The type of condition.",
-											},
-										},
-									},
-								},
-							},
+							Description: "Configure options used when deleting the resource.",
 							Attributes: []*tfprotov5.SchemaAttribute{
 								{
-									Name:        "rollout",
-									Type:        tftypes.Bool,
+									Name:        "propagation_policy",
+									Type:        tftypes.String,
 									Optional:    true,
-									Description: "Wait for rollout to complete on resources that support `kubectl rollout status`.",
+									Description: "Whether and how garbage collection is performed for the dependents of the resource. One of `Foreground`, `Background` or `Orphan`. Defaults to the policy of the resource kind.",
 								},
 								{
-									Name:        "fields",
-									Type:        tftypes.Map{ElementType: tftypes.String},
+									Name:        "grace_period_seconds",
+									Type:        tftypes.Number,
 									Optional:    true,
-									Description: "A map of paths to fields to wait for a specific field value.",
+									Description: "The duration in seconds before the resource is deleted. Zero means delete immediately. Defaults to the grace period of the resource kind.",
 								},
 							},
 						},
 					},
+					{
+						TypeName: "wait",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 0,
+						MaxItems: 1,
+						Block: waitBlockSchema(&tfprotov5.SchemaAttribute{
+							Name:        "rollout",
+							Type:        tftypes.Bool,
+							Optional:    true,
+							Description: "Wait for rollout to complete on resources that support `kubectl rollout status`.",
+						}),
+					},
 				},
 				Attributes: []*tfprotov5.SchemaAttribute{
 					{
 						Name:        "manifest",
 						Type:        tftypes.DynamicPseudoType,
-						Required:    true,
-						Description: "A Kubernetes manifest describing the desired state of the resource in HCL format.",
+						Optional:    true,
+						Description: "A Kubernetes manifest describing the desired state of the resource in HCL format. Conflicts with `manifest_yaml`.",
+					},
+					{
+						Name:        "manifest_yaml",
+						Type:        tftypes.String,
+						Optional:    true,
+						Description: "A Kubernetes manifest describing the desired state of the resource in YAML format. Values are interpreted using the resource schema, the way `kubectl` would. Conflicts with `manifest`.",
 					},
 					{
 						Name:        "object",
@@ -210,6 +217,202 @@ func GetProviderResourceSchema() map[string]*tfprotov5.Schema {
 						Description: "List of manifest fields whose values can be altered by the API server during 'apply'. Defaults to: [\"metadata.annotations\", \"metadata.labels\"]",
 						Optional:    true,
 					},
+					{
+						Name:        "owned_fields_only",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Only detect drift in the fields of the manifest owned by the field manager, as recorded in `metadata.managedFields`. Changes made by other field managers to fields they took ownership of are ignored.",
+					},
+					{
+						Name:        "plan_with_dry_run",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Plan the fields of `object` not set in the manifest with the values returned by a server-side dry-run apply, including the defaults and the changes of mutating admission webhooks. The fields listed in `computed_fields` are left unknown.",
+					},
+					{
+						Name:        "subresource",
+						Type:        tftypes.String,
+						Optional:    true,
+						Description: "Apply the manifest to a subresource of an existing resource instead of the resource itself. One of `status` or `scale`. The field manager defaults to `Terraform-<subresource>`, and destroying the resource leaves the Kubernetes resource in place.",
+					},
+				},
+			},
+		},
+		"kubernetes_manifests": {
+			Version: 0,
+			Block: &tfprotov5.SchemaBlock{
+				Description: "Applies a stream of Kubernetes manifests as a single unit.",
+				BlockTypes: []*tfprotov5.SchemaNestedBlock{
+					{
+						TypeName: "timeouts",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 0,
+						MaxItems: 1,
+						Block: &tfprotov5.SchemaBlock{
+							Attributes: []*tfprotov5.SchemaAttribute{
+								{
+									Name:        "create",
+									Type:        tftypes.String,
+									Description: "Timeout for the create operation.",
+									Optional:    true,
+								},
+								{
+									Name:        "update",
+									Type:        tftypes.String,
+									Description: "Timeout for the update operation.",
+									Optional:    true,
+								},
+								{
+									Name:        "delete",
+									Type:        tftypes.String,
+									Description: "Timeout for the delete operation.",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					{
+						TypeName: "field_manager",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 0,
+						MaxItems: 1,
+						Block: &tfprotov5.SchemaBlock{
+							Description: "Configure field manager options.",
+							Attributes: []*tfprotov5.SchemaAttribute{
+								{
+									Name:        "name",
+									Type:        tftypes.String,
+									Optional:    true,
+									Description: "The name to use for the field manager when creating and updating the resources.",
+								},
+								{
+									Name:        "force_conflicts",
+									Type:        tftypes.Bool,
+									Optional:    true,
+									Description: "Force changes against conflicts.",
+								},
+							},
+						},
+					},
+				},
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:        "content",
+						Type:        tftypes.String,
+						Required:    true,
+						Description: "A stream of Kubernetes manifests in YAML format, separated by `---`.",
+					},
+					{
+						Name:        "objects",
+						Type:        tftypes.List{ElementType: manifestsObjectType},
+						Computed:    true,
+						Description: "The objects managed by this resource, in the order they appear in `content`.",
+					},
+				},
+			},
+		},
+		"kubernetes_manifest_patch": {
+			Version: 0,
+			Block: &tfprotov5.SchemaBlock{
+				Description: "Applies a partial manifest to an existing Kubernetes resource with server-side apply, and manages only the fields set in the manifest.",
+				BlockTypes: []*tfprotov5.SchemaNestedBlock{
+					{
+						TypeName: "timeouts",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 0,
+						MaxItems: 1,
+						Block: &tfprotov5.SchemaBlock{
+							Attributes: []*tfprotov5.SchemaAttribute{
+								{
+									Name:        "create",
+									Type:        tftypes.String,
+									Description: "Timeout for the create operation.",
+									Optional:    true,
+								},
+								{
+									Name:        "update",
+									Type:        tftypes.String,
+									Description: "Timeout for the update operation.",
+									Optional:    true,
+								},
+								{
+									Name:        "delete",
+									Type:        tftypes.String,
+									Description: "Timeout for the delete operation.",
+									Optional:    true,
+								},
+							},
+						},
+					},
+					{
+						TypeName: "field_manager",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 0,
+						MaxItems: 1,
+						Block: &tfprotov5.SchemaBlock{
+							Description: "Configure field manager options.",
+							Attributes: []*tfprotov5.SchemaAttribute{
+								{
+									Name:        "name",
+									Type:        tftypes.String,
+									Optional:    true,
+									Description: "The name of the field manager that owns the patched fields. Defaults to `Terraform-patch`. Patches of the same resource need distinct field managers.",
+								},
+								{
+									Name:        "force_conflicts",
+									Type:        tftypes.Bool,
+									Optional:    true,
+									Description: "Force changes against conflicts, taking ownership of fields owned by other field managers.",
+								},
+							},
+						},
+					},
+				},
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:        "manifest",
+						Type:        tftypes.DynamicPseudoType,
+						Required:    true,
+						Description: "A partial Kubernetes manifest in HCL format. It identifies an existing resource with `apiVersion`, `kind` and `metadata`, and contains only the fields to manage.",
+					},
+					{
+						Name:        "object",
+						Type:        tftypes.DynamicPseudoType,
+						Computed:    true,
+						Description: "The fields of the resource owned by the field manager, as returned by the API server.",
+					},
+				},
+			},
+		},
+		"kubernetes_applyset": {
+			Version: 0,
+			Block: &tfprotov5.SchemaBlock{
+				Description: "Manages an ApplySet, and prunes objects labelled as members of the set that are no longer declared as members.",
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:        "name",
+						Type:        tftypes.String,
+						Required:    true,
+						Description: "Name of the Secret that is the parent of the ApplySet.",
+					},
+					{
+						Name:        "namespace",
+						Type:        tftypes.String,
+						Required:    true,
+						Description: "Namespace of the Secret that is the parent of the ApplySet.",
+					},
+					{
+						Name:        "members",
+						Type:        tftypes.List{ElementType: manifestsObjectType},
+						Optional:    true,
+						Description: "The objects that belong to the ApplySet. Objects labelled as members of the ApplySet that are not listed here are deleted.",
+					},
+					{
+						Name:        "id",
+						Type:        tftypes.String,
+						Computed:    true,
+						Description: "The ID of the ApplySet, which objects are labelled with to mark them as members of the set.",
+					},
 				},
 			},
 		},
@@ -267,6 +470,18 @@ func GetProviderDataSourceSchema() map[string]*tfprotov5.Schema {
 							},
 						},
 					},
+					{
+						TypeName: "wait",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 0,
+						MaxItems: 1,
+						Block: waitBlockSchema(&tfprotov5.SchemaAttribute{
+							Name:        "timeout",
+							Type:        tftypes.String,
+							Optional:    true,
+							Description: "How long to wait for the resource to exist and match the wait conditions. Defaults to `10m`.",
+						}),
+					},
 				},
 			},
 		},
@@ -317,8 +532,132 @@ func GetProviderDataSourceSchema() map[string]*tfprotov5.Schema {
 						Optional:    true,
 						Description: "Limit is a maximum number of responses to return for a list call.",
 					},
+					{
+						Name:        "page_size",
+						Type:        tftypes.Number,
+						Optional:    true,
+						Description: "The number of objects to request from the API server at a time. When set, pages are requested until all objects, or `limit` objects, have been returned. Defaults to requesting all objects at once.",
+					},
+					{
+						Name:        "all_namespaces",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "List objects across all namespaces. Conflicts with `namespace`.",
+					},
+					{
+						Name:        "fields",
+						Type:        tftypes.List{ElementType: tftypes.String},
+						Optional:    true,
+						Description: "The paths of the fields to keep in `objects`, such as `metadata.name` or `status.phase`. Other fields are left out. Defaults to keeping all fields.",
+					},
+					{
+						Name:        "metadata_only",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Request only the `apiVersion`, `kind` and `metadata` of the objects from the API server.",
+					},
+					{
+						Name:        "resource_version",
+						Type:        tftypes.String,
+						Computed:    true,
+						Description: "The resource version of the list, identifying the state of the cluster the objects were listed from.",
+					},
+					{
+						Name:        "remaining_item_count",
+						Type:        tftypes.Number,
+						Computed:    true,
+						Description: "The number of objects left out of `objects` because of `limit`, when reported by the API server.",
+					},
 				},
 			},
 		},
 	}
 }
+
+// waitBlockSchema returns the schema of the 'wait' block shared by the kubernetes_manifest resource
+// and the kubernetes_resource data source, with the additional attributes given
+func waitBlockSchema(attributes ...*tfprotov5.SchemaAttribute) *tfprotov5.SchemaBlock {
+	return &tfprotov5.SchemaBlock{
+		Description: "Configure waiter options.",
+		BlockTypes: []*tfprotov5.SchemaNestedBlock{
+			{
+				TypeName: "condition",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				Block: &tfprotov5.SchemaBlock{
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:        "status",
+							Type:        tftypes.String,
+							Optional:    true,
+							Description: "The condition status.",
+						}, {
+							Name:        "type",
+							Type:        tftypes.String,
+							Optional:    true,
+							Description: "The type of condition.",
+						},
+					},
+				},
+			},
+			{
+				TypeName: "error_on",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Stop waiting with an error as soon as the resource reaches one of these states.",
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "condition",
+							Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+							MinItems: 0,
+							Block: &tfprotov5.SchemaBlock{
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:        "type",
+										Type:        tftypes.String,
+										Required:    true,
+										Description: "The type of condition.",
+									}, {
+										Name:        "status",
+										Type:        tftypes.String,
+										Optional:    true,
+										Description: "The condition status. Matches any status when not set.",
+									}, {
+										Name:        "reason",
+										Type:        tftypes.String,
+										Optional:    true,
+										Description: "The condition reason. Matches any reason when not set.",
+									},
+								},
+							},
+						},
+					},
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:        "fields",
+							Type:        tftypes.Map{ElementType: tftypes.String},
+							Optional:    true,
+							Description: "A map of paths to fields to regular expressions matching error values.",
+						},
+					},
+				},
+			},
+		},
+		Attributes: append([]*tfprotov5.SchemaAttribute{
+			{
+				Name:        "fields",
+				Type:        tftypes.Map{ElementType: tftypes.String},
+				Optional:    true,
+				Description: "A map of paths to fields to wait for a specific field value.",
+			},
+			{
+				Name:        "expression",
+				Type:        tftypes.String,
+				Optional:    true,
+				Description: "A CEL expression to wait for to be true. The resource is available to the expression as `object`.",
+			},
+		}, attributes...),
+	}
+}
@@ -112,6 +112,17 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "config_raw",
+				Type:            tftypes.String,
+				Description:     "Content of a kube config file, as a string. Conflicts with `config_path` and `config_paths`, and the context to use is selected with the `config_context*` attributes. Can be sourced from `KUBE_CONFIG_RAW`, which takes precedence over the kube config paths.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       true,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 			{
 				Name:            "config_path",
 				Type:            tftypes.String,
@@ -200,6 +211,105 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "default_annotations",
+				Type:            tftypes.Map{ElementType: tftypes.String},
+				Description:     "Map of annotations added to the metadata of every object managed by this provider. Annotations set on a resource take precedence over the ones set here.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "default_labels",
+				Type:            tftypes.Map{ElementType: tftypes.String},
+				Description:     "Map of labels added to the metadata of every object managed by this provider. Labels set on a resource take precedence over the ones set here.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "offline_schema_version",
+				Type:            tftypes.String,
+				Description:     "Kubernetes minor version (e.g. `1.33`) of the OpenAPI schema bundled with the provider. When set, `kubernetes_manifest` resources of built-in kinds are planned against the bundled schema instead of contacting the API server. Can be sourced from `KUBE_OFFLINE_SCHEMA_VERSION`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "openapi_cache_dir",
+				Type:            tftypes.String,
+				Description:     "Path to a directory where OpenAPI documents retrieved from the API server are cached across provider runs. Cached documents are keyed by the API server address and version, and revalidated with the API server before use. Can be sourced from `KUBE_OPENAPI_CACHE_DIR`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "applyset_parent",
+				Type:            tftypes.String,
+				Description:     "Reference to the Secret, in `<namespace>/<name>` format, that is the parent of the ApplySet that objects created by this provider are labelled as members of. See the `kubernetes_applyset` resource. Can be sourced from `KUBE_APPLYSET_PARENT`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "qps",
+				Type:            tftypes.Number,
+				Description:     "Maximum number of requests per second to the Kubernetes API server, once the `burst` allowance is used up. Defaults to 5. A negative value disables client-side rate limiting. Can be sourced from `KUBE_QPS`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "burst",
+				Type:            tftypes.Number,
+				Description:     "Maximum number of requests sent to the Kubernetes API server in a burst, above the `qps` rate. Defaults to 10. Can be sourced from `KUBE_BURST`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "request_timeout",
+				Type:            tftypes.String,
+				Description:     "Timeout of each request to the Kubernetes API server, as a duration like `30s` or `2m`. Zero, the default, means no timeout. Can be sourced from `KUBE_REQUEST_TIMEOUT`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "max_retries",
+				Type:            tftypes.Number,
+				Description:     "Maximum number of times a request to the Kubernetes API is retried after a transient error, like throttling or an unavailable control plane. Defaults to 5. Set to 0 to disable retries. Can be sourced from `KUBE_MAX_RETRIES`.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 		},
 		BlockTypes: []*tfprotov5.SchemaNestedBlock{
 			{
@@ -252,6 +362,85 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 					},
 				},
 			},
+			{
+				TypeName: "impersonate",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Impersonate a user, and optionally groups, for all the requests to the Kubernetes API, like the `--as` and `--as-group` flags of `kubectl`.",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:            "user",
+							Type:            tftypes.String,
+							Description:     "Username to impersonate. Service accounts are impersonated as `system:serviceaccount:<namespace>:<name>`.",
+							Required:        true,
+							Optional:        false,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "uid",
+							Type:            tftypes.String,
+							Description:     "UID of the user to impersonate.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "groups",
+							Type:            tftypes.List{ElementType: tftypes.String},
+							Description:     "Groups to impersonate.",
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+					},
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "extra",
+							Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+							MinItems: 0,
+							MaxItems: 0,
+							Block: &tfprotov5.SchemaBlock{
+								Description: "Extra information about the user to impersonate, like the `--as-user-extra` flag of `kubectl`.",
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:            "key",
+										Type:            tftypes.String,
+										Description:     "Key of the extra information.",
+										Required:        true,
+										Optional:        false,
+										Computed:        false,
+										Sensitive:       false,
+										DescriptionKind: 0,
+										Deprecated:      false,
+									},
+									{
+										Name:            "values",
+										Type:            tftypes.List{ElementType: tftypes.String},
+										Description:     "Values of the extra information.",
+										Required:        true,
+										Optional:        false,
+										Computed:        false,
+										Sensitive:       false,
+										DescriptionKind: 0,
+										Deprecated:      false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				TypeName: "experiments",
 				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
@@ -39,6 +39,16 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 		return resp, nil
 	}
 
+	if req.TypeName == manifestsResourceType {
+		return s.readManifests(ctx, req)
+	}
+	if req.TypeName == applySetResourceType {
+		return s.readApplySet(ctx, req)
+	}
+	if req.TypeName == manifestPatchResourceType {
+		return s.readManifestPatch(ctx, req)
+	}
+
 	var resState map[string]tftypes.Value
 	var err error
 	rt, err := GetResourceType(req.TypeName)
@@ -106,7 +116,22 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 		return resp, nil
 	}
 
-	objectType, th, err := s.TFTypeFromOpenAPI(ctx, gvk, false)
+	subresource, err := getSubresource(resState)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid subresource",
+			Detail:    err.Error(),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("subresource"),
+		})
+		return resp, nil
+	}
+	var subresources []string
+	if subresource != "" {
+		subresources = append(subresources, subresource)
+	}
+
+	objectType, th, err := s.subresourceType(ctx, gvk, subresource)
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 			Severity: tfprotov5.DiagnosticSeverityError,
@@ -153,9 +178,9 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 
 	var ro *unstructured.Unstructured
 	if ns {
-		ro, err = rcl.Namespace(rnamespace).Get(ctx, rname, metav1.GetOptions{})
+		ro, err = rcl.Namespace(rnamespace).Get(ctx, rname, metav1.GetOptions{}, subresources...)
 	} else {
-		ro, err = rcl.Get(ctx, rname, metav1.GetOptions{})
+		ro, err = rcl.Get(ctx, rname, metav1.GetOptions{}, subresources...)
 	}
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -170,7 +195,51 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 		return resp, nil
 	}
 
-	fo := RemoveServerSideFields(ro.Object)
+	var ownedOnly bool
+	if oo, ok := resState["owned_fields_only"]; ok && oo.IsKnown() && !oo.IsNull() {
+		oo.As(&ownedOnly)
+	}
+	var ownedSet map[string]interface{}
+	if ownedOnly && subresource == "" {
+		fieldManagerName, _, err := s.getFieldManagerConfig(resState)
+		if err == nil {
+			ownedSet, err = managerFieldSet(ro.GetManagedFields(), fieldManagerName)
+		}
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to determine the fields owned by the field manager",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+	}
+
+	var fo map[string]interface{}
+	if subresource != "" {
+		fo = subresourceObject(ro.Object, gvk, subresource)
+	} else {
+		fo = RemoveServerSideFields(ro.Object)
+	}
+	if ownedSet != nil {
+		// only the fields owned by the field manager are compared to the manifest
+		mv, err := manifestValue(resState, objectType, th)
+		if err == nil {
+			var mu interface{}
+			mu, err = payload.FromTFValue(mv, th, tftypes.NewAttributePath())
+			if err == nil {
+				fo, _ = ownedFieldsOnly(fo, cu, mu, ownedSet).(map[string]interface{})
+			}
+		}
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to decode the manifest of the resource",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+	}
 	nobj, err := payload.ToTFValue(fo, objectType, th, tftypes.NewAttributePath())
 	if err != nil {
 		return resp, err
@@ -196,7 +265,7 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 	resp.NewState = &newState
 
 	// set resource identity data
-	idData, err := createIdentityData(ro)
+	idData, err := createIdentityData(&unstructured.Unstructured{Object: fo})
 	if err != nil {
 		return resp, err
 	}
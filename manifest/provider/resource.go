@@ -36,35 +36,22 @@ func GVRFromUnstructured(o *unstructured.Unstructured, m meta.RESTMapper) (schem
 // GVKFromTftypesObject extracts a canonical schema.GroupVersionKind out of the resource's
 // metadata by checking it against the discovery API via a RESTMapper
 func GVKFromTftypesObject(in *tftypes.Value, m meta.RESTMapper) (schema.GroupVersionKind, error) {
-	var obj map[string]tftypes.Value
-	err := in.As(&obj)
+	gvk, err := gvkFromTftypesObject(in)
 	if err != nil {
 		return schema.GroupVersionKind{}, err
 	}
-	var apv string
-	var kind string
-	err = obj["apiVersion"].As(&apv)
-	if err != nil {
-		return schema.GroupVersionKind{}, err
-	}
-	err = obj["kind"].As(&kind)
-	if err != nil {
-		return schema.GroupVersionKind{}, err
-	}
-	gv, err := schema.ParseGroupVersion(apv)
-	if err != nil {
-		return schema.GroupVersionKind{}, err
-	}
-	mappings, err := m.RESTMappings(gv.WithKind(kind).GroupKind())
+	mappings, err := m.RESTMappings(gvk.GroupKind())
 	if err != nil {
 		return schema.GroupVersionKind{}, err
 	}
 	for _, m := range mappings {
-		if m.GroupVersionKind.GroupVersion().String() == apv {
+		if m.GroupVersionKind.GroupVersion() == gvk.GroupVersion() {
 			return m.GroupVersionKind, nil
 		}
 	}
-	return schema.GroupVersionKind{}, errors.New("cannot select exact GV from REST mapper")
+	// the kind is known to the API, but not in the requested version - this is a configuration error,
+	// unlike a kind that is not known at all, which may be defined by a CRD that is not installed yet
+	return schema.GroupVersionKind{}, fmt.Errorf("cannot select exact GV from REST mapper: %s is not served in version %q", gvk.GroupKind(), gvk.Version)
 }
 
 // IsResourceNamespaced determines if a resource is namespaced or cluster-level
@@ -86,14 +73,23 @@ func (ps *RawProviderServer) TFTypeFromOpenAPI(ctx context.Context, gvk schema.G
 	var tsch tftypes.Type
 	var hints map[string]string
 
-	oapi, err := ps.getOAPIv2Foundry()
+	if _, ok := ps.lookUpOfflineGVK(gvk); ok {
+		// built-in type described by the bundled OpenAPI spec - no need to ask the API server
+		oapi, err := ps.getOfflineOAPIFoundry()
+		if err != nil {
+			return nil, hints, fmt.Errorf("cannot get bundled OpenAPI foundry: %s", err)
+		}
+		return resourceTypeFromFoundry(oapi, gvk, status)
+	}
+
+	oapi, err := ps.getOAPIFoundry()
 	if err != nil {
-		return nil, hints, fmt.Errorf("cannot get OpenAPI foundry: %s", err)
+		return nil, hints, fmt.Errorf("cannot get OpenAPI foundry: %w", err)
 	}
 	// check if GVK is from a CRD
 	crdSchema, err := ps.lookUpGVKinCRDs(ctx, gvk)
 	if err != nil {
-		return nil, hints, fmt.Errorf("failed to look up GVK [%s] among available CRDs: %s", gvk.String(), err)
+		return nil, hints, fmt.Errorf("failed to look up GVK [%s] among available CRDs: %w", gvk.String(), err)
 	}
 	if crdSchema != nil {
 		js, err := json.Marshal(openapi.SchemaToSpec("", crdSchema.(map[string]interface{})))
@@ -111,11 +107,25 @@ func (ps *RawProviderServer) TFTypeFromOpenAPI(ctx context.Context, gvk schema.G
 	}
 	if tsch == nil {
 		// Not a CRD type - look GVK up in cluster OpenAPI spec
-		tsch, hints, err = oapi.GetTypeByGVK(gvk)
-		if err != nil {
-			return nil, hints, fmt.Errorf("cannot get resource type from OpenAPI (%s): %s", gvk.String(), err)
-		}
+		return resourceTypeFromFoundry(oapi, gvk, status)
 	}
+	tsch, err = backfillResourceType(oapi, tsch, status)
+	return tsch, hints, err
+}
+
+// resourceTypeFromFoundry looks up the type of a built-in resource in an OpenAPI foundry
+func resourceTypeFromFoundry(oapi openapi.Foundry, gvk schema.GroupVersionKind, status bool) (tftypes.Type, map[string]string, error) {
+	tsch, hints, err := oapi.GetTypeByGVK(gvk)
+	if err != nil {
+		return nil, hints, fmt.Errorf("cannot get resource type from OpenAPI (%s): %s", gvk.String(), err)
+	}
+	tsch, err = backfillResourceType(oapi, tsch, status)
+	return tsch, hints, err
+}
+
+// backfillResourceType removes the "status" attribute from a resource type, unless requested,
+// and fills in the apiVersion, kind and metadata attributes
+func backfillResourceType(oapi openapi.Foundry, tsch tftypes.Type, status bool) (tftypes.Type, error) {
 	// remove "status" attribute from resource type
 	if tsch.Is(tftypes.Object{}) && !status {
 		ot := tsch.(tftypes.Object)
@@ -135,14 +145,62 @@ func (ps *RawProviderServer) TFTypeFromOpenAPI(ctx context.Context, gvk schema.G
 		}
 		metaType, _, err := oapi.GetTypeByGVK(openapi.ObjectMetaGVK)
 		if err != nil {
-			return nil, hints, fmt.Errorf("failed to generate tftypes for v1.ObjectMeta: %s", err)
+			return nil, fmt.Errorf("failed to generate tftypes for v1.ObjectMeta: %s", err)
 		}
 		atts["metadata"] = metaType.(tftypes.Object)
 
 		tsch = tftypes.Object{AttributeTypes: atts}
 	}
 
-	return tsch, hints, nil
+	return tsch, nil
+}
+
+// lookUpOfflineGVK checks if a GVK is described by the OpenAPI spec bundled with the provider
+// and returns whether the resource is namespaced. It always fails when 'offline_schema_version'
+// is not configured, or for kinds not built into Kubernetes (e.g. from CRDs).
+func (ps *RawProviderServer) lookUpOfflineGVK(gvk schema.GroupVersionKind) (namespaced bool, ok bool) {
+	if ps.offlineSchemaVersion == "" {
+		return false, false
+	}
+	oapi, err := ps.getOfflineOAPIFoundry()
+	if err != nil {
+		ps.logger.Warn("[lookUpOfflineGVK]", "failed to load bundled OpenAPI spec", err)
+		return false, false
+	}
+	sf, ok := oapi.(openapi.ScopeFinder)
+	if !ok {
+		return false, false
+	}
+	namespaced, err = sf.IsNamespaced(gvk)
+	if err != nil {
+		return false, false
+	}
+	return namespaced, true
+}
+
+// gvkFromTftypesObject extracts a schema.GroupVersionKind out of the resource's
+// apiVersion and kind attributes, without checking it against the discovery API
+func gvkFromTftypesObject(in *tftypes.Value) (schema.GroupVersionKind, error) {
+	var obj map[string]tftypes.Value
+	err := in.As(&obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	var apv string
+	var kind string
+	err = obj["apiVersion"].As(&apv)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	err = obj["kind"].As(&kind)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	gv, err := schema.ParseGroupVersion(apv)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gv.WithKind(kind), nil
 }
 
 func mapRemoveNulls(in map[string]interface{}) map[string]interface{} {
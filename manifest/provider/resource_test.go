@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestRemoveNulls(t *testing.T) {
@@ -69,3 +73,43 @@ func TestRemoveNulls(t *testing.T) {
 		})
 	}
 }
+
+func TestGVKFromTftypesObject(t *testing.T) {
+	m := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	m.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	manifest := func(apiVersion, kind string) tftypes.Value {
+		return tftypes.NewValue(tftypes.Object{
+			AttributeTypes: map[string]tftypes.Type{
+				"apiVersion": tftypes.String,
+				"kind":       tftypes.String,
+			},
+		}, map[string]tftypes.Value{
+			"apiVersion": tftypes.NewValue(tftypes.String, apiVersion),
+			"kind":       tftypes.NewValue(tftypes.String, kind),
+		})
+	}
+
+	v := manifest("apps/v1", "Deployment")
+	gvk, err := GVKFromTftypesObject(&v, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gvk.Version != "v1" {
+		t.Fatalf("unexpected GVK %s", gvk)
+	}
+
+	// a known kind in a version that is not served is a configuration error
+	v = manifest("apps/v2", "Deployment")
+	_, err = GVKFromTftypesObject(&v, m)
+	if err == nil || meta.IsNoMatchError(err) {
+		t.Fatalf("expected an error other than a missing kind, got %v", err)
+	}
+
+	// an unknown kind may be defined by a CRD that is not installed yet
+	v = manifest("example.com/v1", "Widget")
+	_, err = GVKFromTftypesObject(&v, m)
+	if !meta.IsNoMatchError(err) {
+		t.Fatalf("expected a missing kind, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,6 +18,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 )
 
@@ -39,14 +41,24 @@ type RawProviderServer struct {
 	clientConfig                *rest.Config
 	clientConfigUnknown         bool
 	dynamicClient               cache[dynamic.Interface]
+	metadataClient              cache[metadata.Interface]
 	discoveryClient             cache[discovery.DiscoveryInterface]
 	restMapper                  cache[meta.RESTMapper]
 	restClient                  cache[rest.Interface]
 	OAPIFoundry                 cache[openapi.Foundry]
+	OAPIv3Foundry               cache[openapi.Foundry]
+	offlineOAPIFoundry          cache[openapi.Foundry]
+	specCache                   cache[*openapi.SpecCache]
 	crds                        cache[[]unstructured.Unstructured]
 	checkValidCredentialsResult cache[[]*tfprotov5.Diagnostic]
-
-	hostTFVersion string
+	apiServerUnreachable        bool
+
+	hostTFVersion        string
+	offlineSchemaVersion string
+	openapiCacheDir      string
+	applySetID           string
+	defaultAnnotations   map[string]string
+	defaultLabels        map[string]string
 }
 
 func dump(v interface{}) hclog.Format {
@@ -87,6 +99,51 @@ func (s *RawProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.GetM
 func (s *RawProviderServer) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
 	s.logger.Trace("[ValidateDataSourceConfig][Request]\n%s\n", dump(*req))
 	resp := &tfprotov5.ValidateDataSourceConfigResponse{}
+
+	dt, err := GetDataSourceType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to determine data source type",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	config, err := req.Config.Unmarshal(dt)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to unmarshal data source configuration",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if config.IsNull() || !config.IsKnown() {
+		return resp, nil
+	}
+	configVal := make(map[string]tftypes.Value)
+	if err := config.As(&configVal); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to extract data source configuration",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	if wait, ok := configVal["wait"]; ok {
+		resp.Diagnostics = append(resp.Diagnostics, validateWaitBlock(wait)...)
+		var waitBlock []tftypes.Value
+		if wait.IsKnown() && !wait.IsNull() && wait.As(&waitBlock) == nil && len(waitBlock) > 0 && waitBlock[0].IsFullyKnown() {
+			if _, err := getDataSourceWaitTimeout(waitBlock[0]); err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "Invalid wait timeout",
+					Detail:    err.Error(),
+					Attribute: tftypes.NewAttributePath().WithAttributeName("wait").WithElementKeyInt(0).WithAttributeName("timeout"),
+				})
+			}
+		}
+	}
 	return resp, nil
 }
 
@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	subresourceStatus = "status"
+	subresourceScale  = "scale"
+)
+
+// scaleGVK is the type of the payload accepted by the 'scale' subresource
+var scaleGVK = schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"}
+
+// getSubresource returns the subresource a 'kubernetes_manifest' resource applies its manifest to,
+// or an empty string when the manifest is applied to the resource itself
+func getSubresource(v map[string]tftypes.Value) (string, error) {
+	sr, ok := v["subresource"]
+	if !ok || sr.IsNull() {
+		return "", nil
+	}
+	if !sr.IsKnown() {
+		return "", fmt.Errorf("the value of 'subresource' must be known during planning")
+	}
+	var subresource string
+	if err := sr.As(&subresource); err != nil {
+		return "", err
+	}
+	switch subresource {
+	case subresourceStatus, subresourceScale:
+		return subresource, nil
+	}
+	return "", fmt.Errorf("unsupported subresource %q, must be one of %q or %q", subresource, subresourceStatus, subresourceScale)
+}
+
+// subresourceType returns the type of a resource, scoped to the attributes that can be applied through a subresource:
+// the 'status' of the resource, or the 'spec.replicas' of its 'scale'.
+func (s *RawProviderServer) subresourceType(ctx context.Context, gvk schema.GroupVersionKind, subresource string) (tftypes.Type, map[string]string, error) {
+	tsch, th, err := s.TFTypeFromOpenAPI(ctx, gvk, subresource == subresourceStatus)
+	if err != nil || subresource == "" {
+		return tsch, th, err
+	}
+	ot, ok := tsch.(tftypes.Object)
+	if !ok {
+		return nil, th, fmt.Errorf("resource %s has no schema, which is required to apply the %q subresource", gvk, subresource)
+	}
+	atts := map[string]tftypes.Type{
+		"apiVersion": ot.AttributeTypes["apiVersion"],
+		"kind":       ot.AttributeTypes["kind"],
+		"metadata":   ot.AttributeTypes["metadata"],
+	}
+	switch subresource {
+	case subresourceStatus:
+		st, ok := ot.AttributeTypes["status"]
+		if !ok {
+			return nil, th, fmt.Errorf("resource %s has no status", gvk)
+		}
+		atts["status"] = st
+	case subresourceScale:
+		atts["spec"] = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"replicas": tftypes.Number,
+		}}
+		// type hints of the resource do not apply to its scale
+		th = map[string]string{}
+	}
+	return tftypes.Object{AttributeTypes: atts}, th, nil
+}
+
+// subresourceObject scopes an object returned by the API to the attributes of the subresource its manifest was applied to.
+// Objects returned by the 'scale' subresource are of kind 'Scale', and are made to look like the resource they scale.
+func subresourceObject(obj map[string]interface{}, gvk schema.GroupVersionKind, subresource string) map[string]interface{} {
+	status := obj["status"]
+	spec, _ := obj["spec"].(map[string]interface{})
+	obj = RemoveServerSideFields(obj)
+	out := map[string]interface{}{
+		"apiVersion": obj["apiVersion"],
+		"kind":       obj["kind"],
+		"metadata":   obj["metadata"],
+	}
+	switch subresource {
+	case subresourceStatus:
+		if status != nil {
+			out["status"] = status
+		}
+	case subresourceScale:
+		out["apiVersion"] = gvk.GroupVersion().String()
+		out["kind"] = gvk.Kind
+		out["spec"] = map[string]interface{}{
+			"replicas": spec["replicas"],
+		}
+	}
+	return out
+}
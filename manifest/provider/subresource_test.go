@@ -0,0 +1,98 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetSubresource(t *testing.T) {
+	samples := map[string]struct {
+		in  tftypes.Value
+		out string
+		err bool
+	}{
+		"null":    {in: tftypes.NewValue(tftypes.String, nil)},
+		"status":  {in: tftypes.NewValue(tftypes.String, "status"), out: subresourceStatus},
+		"scale":   {in: tftypes.NewValue(tftypes.String, "scale"), out: subresourceScale},
+		"invalid": {in: tftypes.NewValue(tftypes.String, "exec"), err: true},
+		"unknown": {in: tftypes.NewValue(tftypes.String, tftypes.UnknownValue), err: true},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			out, err := getSubresource(map[string]tftypes.Value{"subresource": s.in})
+			if (err != nil) != s.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out != s.out {
+				t.Fatalf("expected %q, got %q", s.out, out)
+			}
+		})
+	}
+}
+
+func TestSubresourceObject(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	status := subresourceObject(map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"resourceVersion": "1",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+		"status": map[string]interface{}{
+			"replicas": int64(2),
+		},
+	}, gvk, subresourceStatus)
+	expected := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+		"status": map[string]interface{}{
+			"replicas": int64(2),
+		},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("expected %v, got %v", expected, status)
+	}
+
+	scale := subresourceObject(map[string]interface{}{
+		"apiVersion": "autoscaling/v1",
+		"kind":       "Scale",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"resourceVersion": "1",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+		"status": map[string]interface{}{
+			"replicas": int64(2),
+			"selector": "app=test",
+		},
+	}, gvk, subresourceScale)
+	expected = map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+	}
+	if !reflect.DeepEqual(scale, expected) {
+		t.Errorf("expected %v, got %v", expected, scale)
+	}
+}
@@ -30,6 +30,21 @@ func (s *RawProviderServer) UpgradeResourceState(ctx context.Context, req *tfpro
 		return resp, nil
 	}
 
+	// only the 'object' attribute of kubernetes_manifest may need upgrading
+	if req.TypeName != "kubernetes_manifest" {
+		us, err := tfprotov5.NewDynamicValue(rt, rv)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Failed to encode new state during upgrade",
+				Detail:   err.Error(),
+			})
+		}
+		resp.UpgradedState = &us
+
+		return resp, nil
+	}
+
 	// test if credentials are valid - we're going to need them further down
 	// if no credentials found, just loop the current state back in
 	// we do this to work around https://github.com/hashicorp/terraform/issues/30460
@@ -11,10 +11,21 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // ValidateResourceTypeConfig function
 func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	if req.TypeName == manifestsResourceType {
+		return s.validateManifests(req)
+	}
+	if req.TypeName == applySetResourceType {
+		return s.validateApplySet(req)
+	}
+	if req.TypeName == manifestPatchResourceType {
+		return s.validateManifestPatch(req)
+	}
+
 	resp := &tfprotov5.ValidateResourceTypeConfigResponse{}
 	requiredKeys := []string{"apiVersion", "kind", "metadata"}
 	forbiddenKeys := []string{"status"}
@@ -54,6 +65,35 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 		return resp, nil
 	}
 
+	if sr, ok := configVal["subresource"]; ok && sr.IsKnown() {
+		subresource, err := getSubresource(configVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid subresource",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("subresource"),
+			})
+			return resp, nil
+		}
+		if subresource == subresourceStatus {
+			// the status is what gets applied to the 'status' subresource
+			forbiddenKeys = []string{}
+		}
+	}
+
+	if do, ok := configVal["delete_options"]; ok && do.IsFullyKnown() {
+		if _, err := getDeleteOptions(configVal); err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid delete options",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("delete_options"),
+			})
+			return resp, nil
+		}
+	}
+
 	manifest, ok := configVal["manifest"]
 	if !ok {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -65,6 +105,49 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 		return resp, nil
 	}
 
+	// keys of YAML manifests are reported against the whole attribute, which is a string
+	keyPath := func(key string) *tftypes.AttributePath {
+		return att.WithAttributeName(key)
+	}
+	if manifestYAML, ok := configVal["manifest_yaml"]; ok && !manifestYAML.IsNull() {
+		ypath := tftypes.NewAttributePath().WithAttributeName("manifest_yaml")
+		if !manifest.IsNull() {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Conflicting manifest attributes",
+				Detail:    `Only one of "manifest" or "manifest_yaml" can be set.`,
+				Attribute: ypath,
+			})
+			return resp, nil
+		}
+		if !manifestYAML.IsKnown() {
+			// the manifest will be validated once the YAML is known
+			return resp, nil
+		}
+		manifest, err = manifestValue(configVal, nil, nil)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   `Failed to decode "manifest_yaml" attribute value from resource configuration`,
+				Detail:    err.Error(),
+				Attribute: ypath,
+			})
+			return resp, nil
+		}
+		att = ypath
+		keyPath = func(string) *tftypes.AttributePath {
+			return ypath
+		}
+	} else if manifest.IsNull() {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Manifest missing from resource configuration",
+			Detail:    `One of "manifest" or "manifest_yaml" must be set to a valid Kubernetes resource configuration.`,
+			Attribute: att,
+		})
+		return resp, nil
+	}
+
 	rawManifest := make(map[string]tftypes.Value)
 	err = manifest.As(&rawManifest)
 	if err != nil {
@@ -84,7 +167,7 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 
 	for _, key := range requiredKeys {
 		if _, present := rawManifest[key]; !present {
-			kp := att.WithAttributeName(key)
+			kp := keyPath(key)
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 				Severity:  tfprotov5.DiagnosticSeverityError,
 				Summary:   `Attribute key missing from "manifest" value`,
@@ -96,7 +179,7 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 
 	for _, key := range forbiddenKeys {
 		if _, present := rawManifest[key]; present {
-			kp := att.WithAttributeName(key)
+			kp := keyPath(key)
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 				Severity:  tfprotov5.DiagnosticSeverityError,
 				Summary:   `Forbidden attribute key in "manifest" value`,
@@ -122,34 +205,8 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 	}
 
 	// validate wait block
-	if wait, ok := configVal["wait"]; ok && !wait.IsNull() {
-		var waitBlock []tftypes.Value
-		wait.As(&waitBlock)
-		if len(waitBlock) > 0 {
-			var w map[string]tftypes.Value
-			waitBlock[0].As(&w)
-			waiters := []string{}
-			for k, ww := range w {
-				if !ww.IsNull() {
-					if k == "condition" {
-						var cb []tftypes.Value
-						ww.As(&cb)
-						if len(cb) == 0 {
-							continue
-						}
-					}
-					waiters = append(waiters, k)
-				}
-			}
-			if len(waiters) > 1 {
-				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
-					Severity:  tfprotov5.DiagnosticSeverityError,
-					Summary:   "Invalid wait configuration",
-					Detail:    fmt.Sprintf(`You may only set one of "%s".`, strings.Join(waiters, "\", \"")),
-					Attribute: tftypes.NewAttributePath().WithAttributeName("wait"),
-				})
-			}
-		}
+	if wait, ok := configVal["wait"]; ok {
+		resp.Diagnostics = append(resp.Diagnostics, validateWaitBlock(wait)...)
 	}
 	if waitFor, ok := configVal["wait_for"]; ok && !waitFor.IsNull() {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -163,6 +220,76 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 	return resp, nil
 }
 
+// validateWaitBlock checks that a "wait" block configures at most one waiter,
+// that its CEL expression compiles and that "error_on" is paired with a waiter.
+func validateWaitBlock(wait tftypes.Value) (diags []*tfprotov5.Diagnostic) {
+	if wait.IsNull() || !wait.IsKnown() {
+		return
+	}
+	var waitBlock []tftypes.Value
+	wait.As(&waitBlock)
+	if len(waitBlock) == 0 {
+		return
+	}
+	var w map[string]tftypes.Value
+	waitBlock[0].As(&w)
+	waiters := []string{}
+	for k, ww := range w {
+		if k == "error_on" || k == "timeout" {
+			continue
+		}
+		if !ww.IsNull() {
+			if k == "rollout" {
+				var rollout bool
+				if ww.IsKnown() && ww.As(&rollout) == nil && !rollout {
+					continue
+				}
+			}
+			if k == "condition" {
+				var cb []tftypes.Value
+				ww.As(&cb)
+				if len(cb) == 0 {
+					continue
+				}
+			}
+			waiters = append(waiters, k)
+		}
+	}
+	if len(waiters) > 1 {
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid wait configuration",
+			Detail:    fmt.Sprintf(`You may only set one of "%s".`, strings.Join(waiters, "\", \"")),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("wait"),
+		})
+	}
+	if expr, ok := w["expression"]; ok && expr.IsKnown() && !expr.IsNull() {
+		var expression string
+		expr.As(&expression)
+		if _, err := compileWaitExpression(expression); err != nil {
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Invalid wait expression",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("wait").WithElementKeyInt(0).WithAttributeName("expression"),
+			})
+		}
+	}
+	var errorOn []tftypes.Value
+	if eo, ok := w["error_on"]; ok && !eo.IsNull() {
+		eo.As(&errorOn)
+	}
+	if len(errorOn) > 0 && len(waiters) == 0 {
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid wait configuration",
+			Detail:    `The "error_on" block requires one of "rollout", "condition", "fields" or "expression" to be set.`,
+			Attribute: tftypes.NewAttributePath().WithAttributeName("wait"),
+		})
+	}
+	return
+}
+
 func (s *RawProviderServer) validateResourceOnline(manifest *tftypes.Value) (diags []*tfprotov5.Diagnostic) {
 	rm, err := s.getRestMapper()
 	if err != nil {
@@ -196,6 +323,11 @@ func (s *RawProviderServer) validateResourceOnline(manifest *tftypes.Value) (dia
 			})
 		return
 	}
+	return validateResourceNamespace(manifest, gvk, ns)
+}
+
+// validateResourceNamespace checks that the manifest sets a namespace if, and only if, the resource is namespaced
+func validateResourceNamespace(manifest *tftypes.Value, gvk schema.GroupVersionKind, ns bool) (diags []*tfprotov5.Diagnostic) {
 	nsPath := tftypes.NewAttributePath()
 	nsPath = nsPath.WithAttributeName("metadata").WithAttributeName("namespace")
 	nsVal, restPath, err := tftypes.WalkAttributePath(*manifest, nsPath)
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/google/cel-go/cel"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/kubectl/pkg/polymorphichelpers"
 )
@@ -37,6 +39,31 @@ func (s *RawProviderServer) waitForCompletion(ctx context.Context, waitForBlock
 	return waiter.Wait(ctx)
 }
 
+// waitForResource polls for a resource every pollInterval until it exists and satisfies the waiter configured in waitForBlock,
+// then returns it
+func (s *RawProviderServer) waitForResource(ctx context.Context, waitForBlock tftypes.Value, rs dynamic.ResourceInterface, rname string, rtype tftypes.Type, th map[string]string, pollInterval time.Duration) (*unstructured.Unstructured, error) {
+	for {
+		if deadline, ok := ctx.Deadline(); ok {
+			if time.Now().After(deadline) {
+				return nil, WaiterError{Reason: "resource to exist"}
+			}
+		}
+		_, err := rs.Get(ctx, rname, v1.GetOptions{})
+		if err == nil {
+			break
+		}
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		time.Sleep(pollInterval) // lintignore:R018
+	}
+
+	if err := s.waitForCompletion(ctx, waitForBlock, rs, rname, rtype, th); err != nil {
+		return nil, err
+	}
+	return rs.Get(ctx, rname, v1.GetOptions{})
+}
+
 // Waiter is a simple interface to implement a blocking wait operation
 type Waiter interface {
 	Wait(context.Context) error
@@ -50,6 +77,15 @@ func (e WaiterError) Error() string {
 	return fmt.Sprintf("timed out waiting on %v", e.Reason)
 }
 
+// WaiterFailedError is returned when a resource reaches one of the error states configured in 'error_on'
+type WaiterFailedError struct {
+	Reason string
+}
+
+func (e WaiterFailedError) Error() string {
+	return fmt.Sprintf("resource reached an error state: %v", e.Reason)
+}
+
 // NewResourceWaiter constructs an appropriate Waiter using the supplied waitForBlock configuration
 func NewResourceWaiter(resource dynamic.ResourceInterface, resourceName string, resourceType tftypes.Type, th map[string]string, waitForBlock tftypes.Value, hl hclog.Logger) (Waiter, error) {
 	var waitForBlockVal map[string]tftypes.Value
@@ -58,6 +94,11 @@ func NewResourceWaiter(resource dynamic.ResourceInterface, resourceName string,
 		return nil, err
 	}
 
+	errorMatcher, err := NewErrorMatcher(resourceType, th, waitForBlockVal["error_on"], hl)
+	if err != nil {
+		return nil, err
+	}
+
 	if v, ok := waitForBlockVal["rollout"]; ok {
 		var rollout bool
 		v.As(&rollout)
@@ -65,6 +106,7 @@ func NewResourceWaiter(resource dynamic.ResourceInterface, resourceName string,
 			return &RolloutWaiter{
 				resource,
 				resourceName,
+				errorMatcher,
 				hl,
 			}, nil
 		}
@@ -78,16 +120,56 @@ func NewResourceWaiter(resource dynamic.ResourceInterface, resourceName string,
 				resource,
 				resourceName,
 				conditionsBlocks,
+				errorMatcher,
 				hl,
 			}, nil
 		}
 	}
 
+	if v, ok := waitForBlockVal["expression"]; ok && v.IsKnown() && !v.IsNull() {
+		var expression string
+		v.As(&expression)
+		program, err := compileWaitExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+		return &ExpressionWaiter{
+			resource,
+			resourceName,
+			expression,
+			program,
+			errorMatcher,
+			hl,
+		}, nil
+	}
+
 	fields, ok := waitForBlockVal["fields"]
 	if !ok || fields.IsNull() || !fields.IsKnown() {
+		if errorMatcher != nil {
+			return nil, fmt.Errorf(`the "error_on" block requires one of "rollout", "condition", "fields" or "expression" to be set`)
+		}
 		return &NoopWaiter{}, nil
 	}
 
+	matchers, err := newFieldMatchers(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FieldWaiter{
+		resource,
+		resourceName,
+		resourceType,
+		th,
+		matchers,
+		errorMatcher,
+		hl,
+	}, nil
+
+}
+
+// newFieldMatchers constructs FieldMatchers from a map of field paths to regular expressions
+func newFieldMatchers(fields tftypes.Value) ([]FieldMatcher, error) {
 	if !fields.Type().Is(tftypes.Map{}) {
 		return nil, fmt.Errorf(`"fields" should be a map of strings`)
 	}
@@ -118,16 +200,162 @@ func NewResourceWaiter(resource dynamic.ResourceInterface, resourceName string,
 		}
 		matchers = append(matchers, FieldMatcher{p, re})
 	}
+	return matchers, nil
+}
 
-	return &FieldWaiter{
-		resource,
-		resourceName,
-		resourceType,
-		th,
-		matchers,
-		hl,
-	}, nil
+// ConditionMatcher matches the status conditions of a resource by type, and optionally by status and reason
+type ConditionMatcher struct {
+	conditionType string
+	status        string
+	reason        string
+}
+
+// ErrorMatcher detects the error states configured in the 'error_on' block of a waiter,
+// so that waiting on a resource that will not become ready can stop early
+type ErrorMatcher struct {
+	conditions    []ConditionMatcher
+	fieldMatchers []FieldMatcher
+	resourceType  tftypes.Type
+	typeHints     map[string]string
+	logger        hclog.Logger
+}
+
+// NewErrorMatcher constructs an ErrorMatcher from an 'error_on' block, or returns nil when the block is not configured
+func NewErrorMatcher(resourceType tftypes.Type, th map[string]string, errorOnBlock tftypes.Value, hl hclog.Logger) (*ErrorMatcher, error) {
+	if errorOnBlock.IsNull() || !errorOnBlock.IsKnown() {
+		return nil, nil
+	}
+	var blocks []tftypes.Value
+	if err := errorOnBlock.As(&blocks); err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	var errorOn map[string]tftypes.Value
+	if err := blocks[0].As(&errorOn); err != nil {
+		return nil, err
+	}
+
+	m := &ErrorMatcher{
+		resourceType: resourceType,
+		typeHints:    th,
+		logger:       hl,
+	}
+	if v, ok := errorOn["condition"]; ok && v.IsKnown() && !v.IsNull() {
+		var conditionBlocks []tftypes.Value
+		v.As(&conditionBlocks)
+		for _, c := range conditionBlocks {
+			var condition map[string]tftypes.Value
+			c.As(&condition)
+			var cm ConditionMatcher
+			condition["type"].As(&cm.conditionType)
+			condition["status"].As(&cm.status)
+			condition["reason"].As(&cm.reason)
+			m.conditions = append(m.conditions, cm)
+		}
+	}
+	if v, ok := errorOn["fields"]; ok && v.IsKnown() && !v.IsNull() {
+		matchers, err := newFieldMatchers(v)
+		if err != nil {
+			return nil, err
+		}
+		m.fieldMatchers = matchers
+	}
+	return m, nil
+}
 
+// Check returns a WaiterFailedError when the resource is in one of the configured error states
+func (m *ErrorMatcher) Check(res *unstructured.Unstructured) error {
+	if m == nil {
+		return nil
+	}
+	if status, ok := res.Object["status"].(map[string]interface{}); ok {
+		conditions, _ := status["conditions"].([]interface{})
+		for _, cm := range m.conditions {
+			for _, c := range conditions {
+				cc, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				ctype, _ := cc["type"].(string)
+				cstatus, _ := cc["status"].(string)
+				creason, _ := cc["reason"].(string)
+				if ctype != cm.conditionType ||
+					(cm.status != "" && cstatus != cm.status) ||
+					(cm.reason != "" && creason != cm.reason) {
+					continue
+				}
+				reason := fmt.Sprintf("condition %q is %q", ctype, cstatus)
+				if creason != "" {
+					reason = fmt.Sprintf("%s with reason %q", reason, creason)
+				}
+				if msg, _ := cc["message"].(string); msg != "" {
+					reason = fmt.Sprintf("%s: %s", reason, msg)
+				}
+				return WaiterFailedError{Reason: reason}
+			}
+		}
+	}
+	if len(m.fieldMatchers) == 0 {
+		return nil
+	}
+
+	resObj := res.DeepCopy().Object
+	if meta, ok := resObj["metadata"].(map[string]interface{}); ok {
+		delete(meta, "managedFields")
+	}
+	obj, err := payload.ToTFValue(resObj, m.resourceType, m.typeHints, tftypes.NewAttributePath())
+	if err != nil {
+		// the resource may not match its type while it is being reconciled, the next poll checks it again
+		m.logger.Debug("[ApplyResourceChange][Wait]", "cannot check the error states of the resource", err)
+		return nil
+	}
+	for _, fm := range m.fieldMatchers {
+		vi, rp, err := tftypes.WalkAttributePath(obj, fm.path)
+		if err != nil || len(rp.Steps()) > 0 {
+			// the field may not be set yet
+			continue
+		}
+		v := vi.(tftypes.Value)
+		if v.IsNull() {
+			continue
+		}
+		s, err := fieldValueString(v)
+		if err != nil {
+			return err
+		}
+		if fm.valueMatcher.Match([]byte(s)) {
+			return WaiterFailedError{Reason: fmt.Sprintf("field %q has value %q", fm.path.String(), s)}
+		}
+	}
+	return nil
+}
+
+// fieldValueString returns the string representation of a primitive value, for matching with a regular expression
+func fieldValueString(v tftypes.Value) (string, error) {
+	var s string
+	switch {
+	case v.Type().Is(tftypes.String):
+		v.As(&s)
+	case v.Type().Is(tftypes.Bool):
+		var vb bool
+		v.As(&vb)
+		s = fmt.Sprintf("%t", vb)
+	case v.Type().Is(tftypes.Number):
+		var f big.Float
+		v.As(&f)
+		if f.IsInt() {
+			i, _ := f.Int64()
+			s = fmt.Sprintf("%d", i)
+		} else {
+			i, _ := f.Float64()
+			s = fmt.Sprintf("%f", i)
+		}
+	default:
+		return "", fmt.Errorf("wait_for: cannot match on type %q", v.Type().String())
+	}
+	return s, nil
 }
 
 // FieldMatcher contains a tftypes.AttributePath to a field and a regexp to match on it
@@ -144,6 +372,7 @@ type FieldWaiter struct {
 	resourceType  tftypes.Type
 	typeHints     map[string]string
 	fieldMatchers []FieldMatcher
+	errorMatcher  *ErrorMatcher
 	logger        hclog.Logger
 }
 
@@ -167,6 +396,9 @@ func (w *FieldWaiter) Wait(ctx context.Context) error {
 		if errors.IsGone(err) {
 			return fmt.Errorf("resource was deleted")
 		}
+		if err := w.errorMatcher.Check(res); err != nil {
+			return err
+		}
 		resObj := res.Object
 		meta := resObj["metadata"].(map[string]interface{})
 		delete(meta, "managedFields")
@@ -188,27 +420,9 @@ func (w *FieldWaiter) Wait(ctx context.Context) error {
 					return false, fmt.Errorf("attribute not present at path '%s'", m.path.String())
 				}
 
-				var s string
-				v := vi.(tftypes.Value)
-				switch {
-				case v.Type().Is(tftypes.String):
-					v.As(&s)
-				case v.Type().Is(tftypes.Bool):
-					var vb bool
-					v.As(&vb)
-					s = fmt.Sprintf("%t", vb)
-				case v.Type().Is(tftypes.Number):
-					var f big.Float
-					v.As(&f)
-					if f.IsInt() {
-						i, _ := f.Int64()
-						s = fmt.Sprintf("%d", i)
-					} else {
-						i, _ := f.Float64()
-						s = fmt.Sprintf("%f", i)
-					}
-				default:
-					return true, fmt.Errorf("wait_for: cannot match on type %q", v.Type().String())
+				s, err := fieldValueString(vi.(tftypes.Value))
+				if err != nil {
+					return true, err
 				}
 
 				if !m.valueMatcher.Match([]byte(s)) {
@@ -229,6 +443,72 @@ func (w *FieldWaiter) Wait(ctx context.Context) error {
 	}
 }
 
+// compileWaitExpression compiles a CEL expression evaluating to a boolean,
+// with the resource available as the 'object' variable
+func compileWaitExpression(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("invalid CEL expression %q: %s", expression, iss.Err())
+	}
+	if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("CEL expression %q must evaluate to a bool, not %s", expression, ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+// ExpressionWaiter will wait for a CEL expression evaluated against the resource to be true
+type ExpressionWaiter struct {
+	resource     dynamic.ResourceInterface
+	resourceName string
+	expression   string
+	program      cel.Program
+	errorMatcher *ErrorMatcher
+	logger       hclog.Logger
+}
+
+// Wait evaluates the expression against the resource until it returns true.
+// Evaluation errors, such as a reference to a field that is not set yet, count as false.
+func (w *ExpressionWaiter) Wait(ctx context.Context) error {
+	w.logger.Info("[ApplyResourceChange][Wait] Waiting for expression to be true...\n")
+	for {
+		if deadline, ok := ctx.Deadline(); ok {
+			if time.Now().After(deadline) {
+				return WaiterError{Reason: fmt.Sprintf("expression %q", w.expression)}
+			}
+		}
+
+		res, err := w.resource.Get(ctx, w.resourceName, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := w.errorMatcher.Check(res); err != nil {
+			return err
+		}
+
+		out, _, err := w.program.ContextEval(ctx, map[string]interface{}{"object": res.Object})
+		if err != nil {
+			w.logger.Trace("[ApplyResourceChange][Wait]", "expression evaluation failed", err.Error())
+		} else {
+			done, ok := out.Value().(bool)
+			if !ok {
+				return fmt.Errorf("CEL expression %q evaluated to %v, not a bool", w.expression, out.Value())
+			}
+			if done {
+				break
+			}
+		}
+
+		time.Sleep(waiterSleepTime) // lintignore:R018
+	}
+
+	w.logger.Info("[ApplyResourceChange][Wait] Expression is true.\n")
+	return nil
+}
+
 // NoopWaiter is a placeholder for when there is nothing to wait on
 type NoopWaiter struct{}
 
@@ -282,6 +562,7 @@ func FieldPathToTftypesPath(fieldPath string) (*tftypes.AttributePath, error) {
 type RolloutWaiter struct {
 	resource     dynamic.ResourceInterface
 	resourceName string
+	errorMatcher *ErrorMatcher
 	logger       hclog.Logger
 }
 
@@ -303,6 +584,10 @@ func (w *RolloutWaiter) Wait(ctx context.Context) error {
 			return fmt.Errorf("resource was deleted")
 		}
 
+		if err := w.errorMatcher.Check(res); err != nil {
+			return err
+		}
+
 		gk := res.GetObjectKind().GroupVersionKind().GroupKind()
 		statusViewer, err := polymorphichelpers.StatusViewerFor(gk)
 		if err != nil {
@@ -331,6 +616,7 @@ type ConditionsWaiter struct {
 	resource     dynamic.ResourceInterface
 	resourceName string
 	conditions   []tftypes.Value
+	errorMatcher *ErrorMatcher
 	logger       hclog.Logger
 }
 
@@ -353,6 +639,10 @@ func (w *ConditionsWaiter) Wait(ctx context.Context) error {
 			return fmt.Errorf("resource was deleted")
 		}
 
+		if err := w.errorMatcher.Check(res); err != nil {
+			return err
+		}
+
 		if status, ok := res.Object["status"].(map[string]interface{}); ok {
 			if conditions, ok := status["conditions"].([]interface{}); ok && len(conditions) > 0 {
 				conditionsMet := true
@@ -0,0 +1,289 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestErrorMatcher(t *testing.T) {
+	conditionType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"type":   tftypes.String,
+		"status": tftypes.String,
+		"reason": tftypes.String,
+	}}
+	errorOnType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"condition": tftypes.List{ElementType: conditionType},
+		"fields":    tftypes.Map{ElementType: tftypes.String},
+	}}
+	errorOn := tftypes.NewValue(tftypes.List{ElementType: errorOnType}, []tftypes.Value{
+		tftypes.NewValue(errorOnType, map[string]tftypes.Value{
+			"condition": tftypes.NewValue(tftypes.List{ElementType: conditionType}, []tftypes.Value{
+				tftypes.NewValue(conditionType, map[string]tftypes.Value{
+					"type":   tftypes.NewValue(tftypes.String, "Ready"),
+					"status": tftypes.NewValue(tftypes.String, "False"),
+					"reason": tftypes.NewValue(tftypes.String, "Failed"),
+				}),
+			}),
+			"fields": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"status.phase": tftypes.NewValue(tftypes.String, "^Failed$"),
+			}),
+		}),
+	})
+	resourceType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"status": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"phase": tftypes.String,
+			"conditions": tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"type":    tftypes.String,
+				"status":  tftypes.String,
+				"reason":  tftypes.String,
+				"message": tftypes.String,
+			}}},
+		}},
+	}}
+	m, err := NewErrorMatcher(resourceType, map[string]string{}, errorOn, hclog.NewNullLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := func(status, reason string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":    "Ready",
+			"status":  status,
+			"reason":  reason,
+			"message": "the certificate could not be issued",
+		}
+	}
+	samples := map[string]struct {
+		status map[string]interface{}
+		err    string
+	}{
+		"no status": {},
+		"pending": {
+			status: map[string]interface{}{
+				"phase":      "Pending",
+				"conditions": []interface{}{condition("False", "Pending")},
+			},
+		},
+		"condition": {
+			status: map[string]interface{}{
+				"phase":      "Pending",
+				"conditions": []interface{}{condition("False", "Failed")},
+			},
+			err: `condition "Ready" is "False" with reason "Failed": the certificate could not be issued`,
+		},
+		"field": {
+			status: map[string]interface{}{
+				"phase": "Failed",
+			},
+			err: `has value "Failed"`,
+		},
+		"unexpected type": {
+			status: map[string]interface{}{
+				"phase": map[string]interface{}{"name": "Failed"},
+			},
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			obj := map[string]interface{}{}
+			if s.status != nil {
+				obj["status"] = s.status
+			}
+			err := m.Check(&unstructured.Unstructured{Object: obj})
+			if s.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if _, ok := err.(WaiterFailedError); !ok {
+				t.Fatalf("expected a WaiterFailedError, got: %v", err)
+			}
+			if !strings.Contains(err.Error(), s.err) {
+				t.Fatalf("expected error to contain %q, got: %v", s.err, err)
+			}
+		})
+	}
+}
+
+func TestErrorMatcherNotConfigured(t *testing.T) {
+	m, err := NewErrorMatcher(tftypes.DynamicPseudoType, nil, tftypes.Value{}, hclog.NewNullLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != nil {
+		t.Fatalf("expected no matcher")
+	}
+	if err := m.Check(&unstructured.Unstructured{Object: map[string]interface{}{}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestErrorOnWithoutWaiter(t *testing.T) {
+	rt, err := GetResourceType("kubernetes_manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitList := rt.(tftypes.Object).AttributeTypes["wait"].(tftypes.List)
+	waitType := waitList.ElementType.(tftypes.Object)
+	errorOnList := waitType.AttributeTypes["error_on"].(tftypes.List)
+	errorOnType := errorOnList.ElementType.(tftypes.Object)
+
+	waitBlock := objectWithNulls(waitType, map[string]tftypes.Value{
+		"rollout": tftypes.NewValue(tftypes.Bool, false),
+		"error_on": tftypes.NewValue(errorOnList, []tftypes.Value{
+			objectWithNulls(errorOnType, map[string]tftypes.Value{
+				"fields": tftypes.NewValue(errorOnType.AttributeTypes["fields"], map[string]tftypes.Value{
+					"status.phase": tftypes.NewValue(tftypes.String, "Failed"),
+				}),
+			}),
+		}),
+	})
+	diags := validateWaitBlock(tftypes.NewValue(waitList, []tftypes.Value{waitBlock}))
+	if len(diags) != 1 || diags[0].Summary != "Invalid wait configuration" {
+		t.Fatalf("expected an invalid wait configuration, got %d diagnostics", len(diags))
+	}
+	if _, err := NewResourceWaiter(nil, "test", tftypes.DynamicPseudoType, nil, waitBlock, hclog.NewNullLogger()); err == nil {
+		t.Fatal("expected an error for error_on without a waiter")
+	}
+}
+
+func TestCompileWaitExpression(t *testing.T) {
+	samples := map[string]bool{
+		`object.status.readyReplicas == object.spec.replicas`:          true,
+		`object.status.conditions.all(c, c.status == "True")`:          true,
+		`has(object.status.phase) && object.status.phase == "Running"`: true,
+		`object.status.replicas`:                                       true, // the type of fields is only known at evaluation
+		`1 + 1`:                                                        false,
+		`object.status.phase ==`:                                       false,
+		`unknown.status == "foo"`:                                      false,
+	}
+	for expr, valid := range samples {
+		t.Run(expr, func(t *testing.T) {
+			_, err := compileWaitExpression(expr)
+			if (err == nil) != valid {
+				t.Fatalf("unexpected result for %q: %v", expr, err)
+			}
+		})
+	}
+}
+
+func TestExpressionWaiter(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+		},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(2),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+				map[string]interface{}{"type": "Progressing", "status": "True"},
+			},
+		},
+	}}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"}, deployment)
+	rs := client.Resource(gvr).Namespace("default")
+
+	samples := map[string]bool{
+		`object.status.readyReplicas == object.spec.replicas`: true,
+		`object.status.conditions.all(c, c.status == "True")`: true,
+		`object.status.unavailableReplicas == 0`:              false,
+		`object.spec.replicas == 3`:                           false,
+	}
+	for expr, done := range samples {
+		t.Run(expr, func(t *testing.T) {
+			program, err := compileWaitExpression(expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := &ExpressionWaiter{rs, "test", expr, program, nil, hclog.NewNullLogger()}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			err = w.Wait(ctx)
+			if done && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !done {
+				if _, ok := err.(WaiterError); !ok {
+					t.Fatalf("expected the wait to time out, got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForResource(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "SecretList"})
+	rs := client.Resource(gvr).Namespace("default")
+
+	// the secret is created by a controller after a while
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		rs.Create(context.Background(), &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      "generated",
+				"namespace": "default",
+			},
+			"type": "kubernetes.io/tls",
+		}}, metav1.CreateOptions{})
+	}()
+
+	waitType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"expression": tftypes.String,
+		"timeout":    tftypes.String,
+	}}
+	waitBlock := tftypes.NewValue(waitType, map[string]tftypes.Value{
+		"expression": tftypes.NewValue(tftypes.String, `object.type == "kubernetes.io/tls"`),
+		"timeout":    tftypes.NewValue(tftypes.String, "5s"),
+	})
+	timeout, err := getDataSourceWaitTimeout(waitBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout != 5*time.Second {
+		t.Fatalf("unexpected timeout: %s", timeout)
+	}
+
+	s := &RawProviderServer{logger: hclog.NewNullLogger()}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := s.waitForResource(ctx, waitBlock, rs, "generated", tftypes.DynamicPseudoType, map[string]string{}, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.GetName() != "generated" {
+		t.Fatalf("unexpected resource: %v", res.Object)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = s.waitForResource(ctx, waitBlock, rs, "missing", tftypes.DynamicPseudoType, map[string]string{}, 10*time.Millisecond)
+	if _, ok := err.(WaiterError); !ok {
+		t.Fatalf("expected the wait to time out, got: %v", err)
+	}
+}
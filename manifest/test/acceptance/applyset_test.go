@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+// +build acceptance
+
+package acceptance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/provider"
+	tfstatehelper "github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/state"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+)
+
+func TestKubernetesApplySet(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertResourceDoesNotExist(t, "v1", "namespaces", namespace)
+	}()
+
+	tfvars := TFVARS{
+		"namespace": namespace,
+		"name":      name,
+	}
+	tfconfig := loadTerraformConfig(t, "ApplySet/applyset.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+	tf.Apply(ctx)
+
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "secrets", namespace, name)
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "configmaps", namespace, name+"-keep")
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "configmaps", namespace, name+"-prune")
+
+	s, err := tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate := tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_applyset.test.id": util.ApplySetID(name, namespace),
+	})
+	// the label key contains dots, so it can't be part of an attribute address
+	labels, ok := tfstate.GetAttributeValue(t, "kubernetes_manifest.keep.object.metadata.labels").(map[string]interface{})
+	if !ok || labels[util.ApplySetPartOfLabel] != util.ApplySetID(name, namespace) {
+		t.Errorf("expected the ConfigMap to be labelled as a member of the ApplySet, got %v", labels)
+	}
+
+	// the ConfigMap is no longer declared as a member of the set and should be pruned
+	tfconfigModified := loadTerraformConfig(t, "ApplySet/applyset_modified.tf", tfvars)
+	tf.SetConfig(ctx, tfconfigModified)
+	tf.Apply(ctx)
+
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "configmaps", namespace, name+"-keep")
+	k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "configmaps", namespace, name+"-prune")
+}
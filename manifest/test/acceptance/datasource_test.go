@@ -160,3 +160,149 @@ func TestDataSourceKubernetesResources(t *testing.T) {
 		"data.kubernetes_resources.example.objects.2.metadata.labels.test": "terraform",
 	})
 }
+
+func TestDataSourceKubernetesResources_Paging(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	namespace := randName()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	// STEP 1: Create set of ConfigMaps to list
+	configMap1 := tfhelper.RequireNewWorkingDir(ctx, t)
+	configMap1.SetReattachInfo(ctx, reattachInfo)
+	name := randName()
+
+	defer func() {
+		configMap1.Destroy(ctx)
+		configMap1.Close()
+	}()
+
+	cmVars1 := TFVARS{
+		"name_prefix": name,
+		"namespace":   namespace,
+	}
+	cmConfig1 := loadTerraformConfig(t, "datasource_plural/step1.tf", cmVars1)
+	configMap1.SetConfig(ctx, cmConfig1)
+	configMap1.Init(ctx)
+	err = configMap1.Apply(ctx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Step 2: list the ConfigMaps in pages of one, stopping at three of the four labelled ones
+	paged := tfhelper.RequireNewWorkingDir(ctx, t)
+	paged.SetReattachInfo(ctx, reattachInfo)
+
+	defer func() {
+		paged.Destroy(ctx)
+		paged.Close()
+	}()
+
+	pagedVars := TFVARS{
+		"label_selector": "test=terraform",
+		"page_size":      1,
+		"limit":          3,
+		"namespace":      namespace,
+	}
+	pagedConfig := loadTerraformConfig(t, "datasource_plural/step3.tf", pagedVars)
+	paged.SetConfig(ctx, pagedConfig)
+	paged.Init(ctx)
+	err = paged.Apply(ctx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tfState, err := paged.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	state := tfstatehelper.NewHelper(tfState)
+
+	state.AssertAttributeLen(t, "data.kubernetes_resources.example.objects", 3)
+	state.AssertAttributeNotEmpty(t, "data.kubernetes_resources.example.resource_version")
+}
+
+func TestDataSourceKubernetesResources_Fields(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	namespace := randName()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	// STEP 1: Create set of ConfigMaps to list
+	configMap1 := tfhelper.RequireNewWorkingDir(ctx, t)
+	configMap1.SetReattachInfo(ctx, reattachInfo)
+	name := randName()
+
+	defer func() {
+		configMap1.Destroy(ctx)
+		configMap1.Close()
+	}()
+
+	cmVars1 := TFVARS{
+		"name_prefix": name,
+		"namespace":   namespace,
+	}
+	cmConfig1 := loadTerraformConfig(t, "datasource_plural/step1.tf", cmVars1)
+	configMap1.SetConfig(ctx, cmConfig1)
+	configMap1.Init(ctx)
+	err = configMap1.Apply(ctx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Step 2: list only some fields of the ConfigMaps, and only their metadata
+	selected := tfhelper.RequireNewWorkingDir(ctx, t)
+	selected.SetReattachInfo(ctx, reattachInfo)
+
+	defer func() {
+		selected.Destroy(ctx)
+		selected.Close()
+	}()
+
+	selectedVars := TFVARS{
+		"label_selector": "test=terraform",
+		"namespace":      namespace,
+	}
+	selectedConfig := loadTerraformConfig(t, "datasource_plural/step4.tf", selectedVars)
+	selected.SetConfig(ctx, selectedConfig)
+	selected.Init(ctx)
+	err = selected.Apply(ctx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tfState, err := selected.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	state := tfstatehelper.NewHelper(tfState)
+
+	state.AssertAttributeLen(t, "data.kubernetes_resources.fields.objects", 4)
+	state.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"data.kubernetes_resources.fields.objects.0.data.TEST": "hello world",
+	})
+	state.AssertAttributeNotEmpty(t, "data.kubernetes_resources.fields.objects.0.metadata.name")
+	state.AssertAttributeDoesNotExist(t, "data.kubernetes_resources.fields.objects.0.metadata.labels")
+	state.AssertAttributeDoesNotExist(t, "data.kubernetes_resources.fields.objects.0.kind")
+
+	state.AssertAttributeLen(t, "data.kubernetes_resources.metadata.objects", 4)
+	state.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"data.kubernetes_resources.metadata.objects.0.kind":                 "ConfigMap",
+		"data.kubernetes_resources.metadata.objects.0.metadata.labels.test": "terraform",
+	})
+	state.AssertAttributeDoesNotExist(t, "data.kubernetes_resources.metadata.objects.0.data")
+}
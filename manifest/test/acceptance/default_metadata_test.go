@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+// +build acceptance
+
+package acceptance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/provider"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/kubernetes"
+	tfstatehelper "github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/state"
+)
+
+func TestKubernetesManifest_DefaultMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "configmaps", namespace, name)
+	}()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	tfvars := TFVARS{
+		"namespace": namespace,
+		"name":      name,
+	}
+	tfconfig := loadTerraformConfig(t, "DefaultMetadata/default_metadata.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+	tf.Apply(ctx)
+
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "configmaps", namespace, name)
+
+	s, err := tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate := tfstatehelper.NewHelper(s)
+	// the labels set on the resource take precedence over the defaults of the provider
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifest.test.object.metadata.labels.team": "platform",
+		"kubernetes_manifest.test.object.metadata.labels.env":  "staging",
+	})
+	// the annotation key contains dots, so it can't be part of an attribute address
+	annotations, ok := tfstate.GetAttributeValue(t, "kubernetes_manifest.test.object.metadata.annotations").(map[string]interface{})
+	if !ok || annotations["example.com/owner"] != "platform" {
+		t.Errorf("expected the ConfigMap to have the default annotation, got %v", annotations)
+	}
+
+	// the defaults are part of the planned object, so there is nothing to change on the next plan
+	err = tf.CreatePlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %q", err)
+	}
+	plan, err := tf.SavedPlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve saved plan: %q", err)
+	}
+	for _, rc := range plan.ResourceChanges {
+		if !rc.Change.Actions.NoOp() {
+			t.Fatalf("Expected no changes to %s, planned for: %q", rc.Address, rc.Change.Actions)
+		}
+	}
+}
@@ -112,3 +112,75 @@ func TestKubernetesManifest_fieldManager(t *testing.T) {
 		"kubernetes_manifest.test.field_manager.0.force_conflicts": true,
 	})
 }
+
+func TestKubernetesManifest_OwnedFieldsOnly(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "configmaps", namespace, name)
+	}()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	tfvars := TFVARS{
+		"namespace":         namespace,
+		"name":              name,
+		"owned_fields_only": true,
+	}
+	tfconfig := loadTerraformConfig(t, "FieldManager/owned_fields_only.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+	err = tf.Apply(ctx)
+	if err != nil {
+		t.Fatalf("Failed to apply: %q", err)
+	}
+
+	// another field manager changes a field of the manifest, and takes ownership of it
+	k8shelper.PatchNamespacedResource(t, name, namespace, kubernetes.NewGroupVersionResource("v1", "configmaps"),
+		map[string]interface{}{"data": map[string]interface{}{"foo": "changed"}}, "other-controller")
+
+	err = tf.CreatePlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %q", err)
+	}
+	plan, err := tf.SavedPlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve saved plan: %q", err)
+	}
+	for _, rc := range plan.ResourceChanges {
+		if !rc.Change.Actions.NoOp() {
+			t.Fatalf("Expected no changes to %s, planned for: %q", rc.Address, rc.Change.Actions)
+		}
+	}
+
+	// without owned_fields_only, the change is reported as drift
+	tfvars["owned_fields_only"] = false
+	tfconfig = loadTerraformConfig(t, "FieldManager/owned_fields_only.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	err = tf.CreatePlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %q", err)
+	}
+	plan, err = tf.SavedPlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve saved plan: %q", err)
+	}
+	for _, rc := range plan.ResourceChanges {
+		if !rc.Change.Actions.Update() {
+			t.Fatalf("Expected %s to be updated, planned for: %q", rc.Address, rc.Change.Actions)
+		}
+	}
+}
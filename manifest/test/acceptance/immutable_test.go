@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+// +build acceptance
+
+package acceptance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/provider"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/kubernetes"
+	tfstatehelper "github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/state"
+)
+
+func TestKubernetesManifest_ImmutableField(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertNamespacedResourceDoesNotExist(t, "rbac.authorization.k8s.io/v1", "rolebindings", namespace, name)
+	}()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	tfvars := TFVARS{
+		"namespace": namespace,
+		"name":      name,
+	}
+	tfconfig := loadTerraformConfig(t, "Immutable/rolebinding.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+	tf.Apply(ctx)
+
+	k8shelper.AssertNamespacedResourceExists(t, "rbac.authorization.k8s.io/v1", "rolebindings", namespace, name)
+
+	// the roleRef of a RoleBinding cannot be updated, so changing it replaces the resource
+	tfconfig = loadTerraformConfig(t, "Immutable/rolebinding_modified.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	err = tf.CreatePlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %q", err)
+	}
+	plan, err := tf.SavedPlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve saved plan: %q", err)
+	}
+	for _, rc := range plan.ResourceChanges {
+		if !rc.Change.Actions.Replace() {
+			t.Fatalf("Expected %s to be replaced, planned for: %q", rc.Address, rc.Change.Actions)
+		}
+	}
+	tf.Apply(ctx)
+
+	s, err := tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate := tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifest.test.object.roleRef.name": "edit",
+	})
+}
@@ -9,6 +9,8 @@ package acceptance
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
@@ -101,3 +103,62 @@ func TestKubernetesManifest_Import(t *testing.T) {
 		"kubernetes_manifest.test.object.data.fizz":          "buzz",
 	})
 }
+
+func TestKubernetesManifest_ImportMigrateFieldManagers(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "configmaps", namespace, name)
+	}()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	// the ConfigMap is created with an update by the field manager named after the test binary
+	k8shelper.CreateConfigMap(t, name, namespace,
+		map[string]interface{}{
+			"foo":    "bar",
+			"legacy": "value",
+		})
+
+	tfvars := TFVARS{
+		"namespace":    namespace,
+		"name":         name,
+		"migrate_from": filepath.Base(os.Args[0]),
+	}
+	tfconfig := loadTerraformConfig(t, "Import/import_migrate.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+
+	importId := fmt.Sprintf("apiVersion=%s,kind=%s,namespace=%s,name=%s", "v1", "ConfigMap", namespace, name)
+	tf.Import(ctx, "kubernetes_manifest.test", importId)
+
+	err = tf.Apply(ctx)
+	if err != nil {
+		t.Fatalf("Failed to apply: %q", err)
+	}
+
+	// the fields of the migrated field manager are now owned by Terraform, so the ones left out of the manifest are removed
+	s, err := tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate := tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifest.test.object.data.foo":  "bar",
+		"kubernetes_manifest.test.object.data.fizz": "buzz",
+	})
+	tfstate.AssertAttributeDoesNotExist(t, "kubernetes_manifest.test.object.data.legacy")
+}
@@ -0,0 +1,112 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build acceptance
+// +build acceptance
+
+package acceptance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/provider"
+	"github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/kubernetes"
+	tfstatehelper "github.com/hashicorp/terraform-provider-kubernetes/manifest/test/helper/state"
+)
+
+func TestKubernetesManifests(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "configmaps", namespace, name)
+		k8shelper.AssertResourceDoesNotExist(t, "v1", "namespaces", namespace)
+	}()
+
+	tfvars := TFVARS{
+		"namespace": namespace,
+		"name":      name,
+	}
+	tfconfig := loadTerraformConfig(t, "Manifests/manifests.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+	tf.Apply(ctx)
+
+	// the namespace is applied before the objects in it, even though it comes last
+	k8shelper.AssertResourceExists(t, "v1", "namespaces", namespace)
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "configmaps", namespace, name)
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "secrets", namespace, name)
+
+	s, err := tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate := tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeLen(t, "kubernetes_manifests.test.objects", 3)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifests.test.objects.0.kind":      "ConfigMap",
+		"kubernetes_manifests.test.objects.0.name":      name,
+		"kubernetes_manifests.test.objects.0.namespace": namespace,
+		"kubernetes_manifests.test.objects.2.kind":      "Namespace",
+	})
+
+	// the Secret is removed from the stream and should be pruned
+	tfconfigModified := loadTerraformConfig(t, "Manifests/manifests_modified.tf", tfvars)
+	tf.SetConfig(ctx, tfconfigModified)
+	tf.Apply(ctx)
+
+	k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "secrets", namespace, name)
+	k8shelper.AssertNamespacedResourceExists(t, "v1", "configmaps", namespace, name)
+
+	s, err = tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate = tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeLen(t, "kubernetes_manifests.test.objects", 2)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifests.test.objects.0.kind": "Namespace",
+		"kubernetes_manifests.test.objects.1.kind": "ConfigMap",
+	})
+
+	// a field of the manifest changed by another field manager is reported as drift, and applied again
+	k8shelper.PatchNamespacedResource(t, name, namespace, kubernetes.NewGroupVersionResource("v1", "configmaps"),
+		map[string]interface{}{"data": map[string]interface{}{"foo": "changed"}}, "other-controller")
+
+	err = tf.CreatePlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %q", err)
+	}
+	plan, err := tf.SavedPlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve saved plan: %q", err)
+	}
+	for _, rc := range plan.ResourceChanges {
+		if !rc.Change.Actions.Update() {
+			t.Fatalf("Expected %s to be updated, planned for: %q", rc.Address, rc.Change.Actions)
+		}
+	}
+	tf.Apply(ctx)
+
+	s, err = tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate = tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifests.test.objects.1.drifted": false,
+	})
+}
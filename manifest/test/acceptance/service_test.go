@@ -101,3 +101,77 @@ func TestKubernetesManifest_Service(t *testing.T) {
 
 	tfstate.AssertAttributeDoesNotExist(t, "kubernetes_manifest.test.spec")
 }
+
+func TestKubernetesManifest_ServicePlanWithDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	reattachInfo, err := provider.ServeTest(ctx, hclog.Default(), t)
+	if err != nil {
+		t.Errorf("Failed to create provider instance: %q", err)
+	}
+
+	name := randName()
+	namespace := randName()
+
+	tf := tfhelper.RequireNewWorkingDir(ctx, t)
+	tf.SetReattachInfo(ctx, reattachInfo)
+	defer func() {
+		tf.Destroy(ctx)
+		tf.Close()
+		k8shelper.AssertNamespacedResourceDoesNotExist(t, "v1", "services", namespace, name)
+	}()
+
+	k8shelper.CreateNamespace(t, namespace)
+	defer k8shelper.DeleteResource(t, namespace, kubernetes.NewGroupVersionResource("v1", "namespaces"))
+
+	tfvars := TFVARS{
+		"namespace": namespace,
+		"name":      name,
+	}
+	tfconfig := loadTerraformConfig(t, "Service/plan_with_dry_run.tf", tfvars)
+	tf.SetConfig(ctx, tfconfig)
+	tf.Init(ctx)
+
+	err = tf.CreatePlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create plan: %q", err)
+	}
+	plan, err := tf.SavedPlan(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve saved plan: %q", err)
+	}
+	if len(plan.ResourceChanges) != 1 {
+		t.Fatalf("Expected 1 resource change, got %d", len(plan.ResourceChanges))
+	}
+	after, _ := plan.ResourceChanges[0].Change.After.(map[string]interface{})
+	object, _ := after["object"].(map[string]interface{})
+	spec, _ := object["spec"].(map[string]interface{})
+	// the defaults set by the API server are planned
+	if spec["type"] != "ClusterIP" || spec["sessionAffinity"] != "None" {
+		t.Fatalf("Expected the defaults of the service to be planned, got: %v", spec)
+	}
+	ports, _ := spec["ports"].([]interface{})
+	if len(ports) != 1 || ports[0].(map[string]interface{})["protocol"] != "TCP" {
+		t.Fatalf("Expected the protocol of the port to be planned, got: %v", ports)
+	}
+	// the cluster IP is allocated when the service is created
+	if ip, ok := spec["clusterIP"].(string); ok {
+		t.Fatalf("Expected the cluster IP to be unknown, got: %q", ip)
+	}
+
+	err = tf.Apply(ctx)
+	if err != nil {
+		t.Fatalf("Failed to apply: %q", err)
+	}
+
+	s, err := tf.State(ctx)
+	if err != nil {
+		t.Fatalf("Failed to retrieve terraform state: %q", err)
+	}
+	tfstate := tfstatehelper.NewHelper(s)
+	tfstate.AssertAttributeValues(t, tfstatehelper.AttributeValues{
+		"kubernetes_manifest.test.object.spec.ports.0.protocol": "TCP",
+		"kubernetes_manifest.test.object.spec.type":             "ClusterIP",
+	})
+	tfstate.AssertAttributeNotEmpty(t, "kubernetes_manifest.test.object.spec.clusterIP")
+}
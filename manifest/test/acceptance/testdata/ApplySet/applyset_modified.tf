@@ -0,0 +1,58 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+provider "kubernetes" {
+  applyset_parent = "${var.namespace}/${var.name}"
+}
+
+resource "kubernetes_manifest" "namespace" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "Namespace"
+    metadata = {
+      name = var.namespace
+    }
+  }
+}
+
+resource "kubernetes_manifest" "keep" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = "${var.name}-keep"
+      namespace = kubernetes_manifest.namespace.object.metadata.name
+    }
+    data = {
+      foo = "bar"
+    }
+  }
+}
+
+resource "kubernetes_manifest" "prune" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = "${var.name}-prune"
+      namespace = kubernetes_manifest.namespace.object.metadata.name
+    }
+    data = {
+      foo = "bar"
+    }
+  }
+}
+
+resource "kubernetes_applyset" "test" {
+  name      = var.name
+  namespace = kubernetes_manifest.namespace.object.metadata.name
+
+  members = [
+    {
+      api_version = "v1"
+      kind        = "ConfigMap"
+      name        = kubernetes_manifest.keep.object.metadata.name
+      namespace   = var.namespace
+    },
+  ]
+}
@@ -0,0 +1,29 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+provider "kubernetes" {
+  default_labels = {
+    team = "platform"
+    env  = "production"
+  }
+  default_annotations = {
+    "example.com/owner" = "platform"
+  }
+}
+
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+      labels = {
+        env = "staging"
+      }
+    }
+    data = {
+      foo = "bar"
+    }
+  }
+}
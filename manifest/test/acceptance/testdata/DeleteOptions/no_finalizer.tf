@@ -0,0 +1,22 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name       = var.name
+      namespace  = var.namespace
+      finalizers = []
+    }
+    data = {
+      foo = "bar"
+    }
+  }
+
+  delete_options {
+    propagation_policy   = "Background"
+    grace_period_seconds = 0
+  }
+}
@@ -0,0 +1,19 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+    data = {
+      foo = "bar"
+      fizz = "buzz"
+    }
+  }
+
+  owned_fields_only = var.owned_fields_only
+}
@@ -0,0 +1,25 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "rbac.authorization.k8s.io/v1"
+    kind       = "RoleBinding"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+    roleRef = {
+      apiGroup = "rbac.authorization.k8s.io"
+      kind     = "ClusterRole"
+      name     = "edit"
+    }
+    subjects = [
+      {
+        kind      = "ServiceAccount"
+        name      = "default"
+        namespace = var.namespace
+      },
+    ]
+  }
+}
@@ -0,0 +1,19 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "test" {
+  manifest_yaml = <<-EOT
+    apiVersion: v1
+    kind: Service
+    metadata:
+      name: ${var.name}
+      namespace: ${var.namespace}
+    spec:
+      selector:
+        app: test
+      ports:
+        - name: http
+          port: 80
+          targetPort: http
+  EOT
+}
@@ -0,0 +1,27 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifests" "test" {
+  content = <<-EOT
+    apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: ${var.name}
+      namespace: ${var.namespace}
+    data:
+      foo: bar
+    ---
+    apiVersion: v1
+    kind: Secret
+    metadata:
+      name: ${var.name}
+      namespace: ${var.namespace}
+    stringData:
+      password: hunter2
+    ---
+    apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: ${var.namespace}
+  EOT
+}
@@ -0,0 +1,26 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "Service"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+    spec = {
+      ports = [
+        {
+          name = "http",
+          port = 80,
+        }
+      ]
+      selector = {
+        app = "test"
+      }
+    }
+  }
+
+  plan_with_dry_run = true
+}
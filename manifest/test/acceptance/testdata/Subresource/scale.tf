@@ -0,0 +1,53 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "deployment" {
+  manifest = {
+    apiVersion = "apps/v1"
+    kind       = "Deployment"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+    spec = {
+      selector = {
+        matchLabels = {
+          app = "nginx"
+        }
+      }
+      template = {
+        metadata = {
+          labels = {
+            app = "nginx"
+          }
+        }
+        spec = {
+          containers = [
+            {
+              image = "nginx:1"
+              name  = "nginx"
+            },
+          ]
+        }
+      }
+    }
+  }
+}
+
+resource "kubernetes_manifest" "test" {
+  subresource = "scale"
+
+  manifest = {
+    apiVersion = "apps/v1"
+    kind       = "Deployment"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+    spec = {
+      replicas = 2
+    }
+  }
+
+  depends_on = [kubernetes_manifest.deployment]
+}
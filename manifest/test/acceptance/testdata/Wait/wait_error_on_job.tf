@@ -0,0 +1,47 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "test" {
+  manifest = {
+    apiVersion = "batch/v1"
+    kind       = "Job"
+
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+    }
+
+    spec = {
+      backoffLimit = 0
+      template = {
+        spec = {
+          restartPolicy = "Never"
+          containers = [
+            {
+              name    = "fail"
+              image   = "busybox"
+              command = ["sh", "-c", "exit 1"]
+            },
+          ]
+        }
+      }
+    }
+  }
+
+  wait {
+    condition {
+      type   = "Complete"
+      status = "True"
+    }
+    error_on {
+      condition {
+        type   = "Failed"
+        status = "True"
+      }
+    }
+  }
+
+  timeouts {
+    create = "10m"
+  }
+}
@@ -0,0 +1,38 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+resource "kubernetes_manifest" "token" {
+  manifest = {
+    apiVersion = "v1"
+    kind       = "Secret"
+    metadata = {
+      name      = var.name
+      namespace = var.namespace
+      annotations = {
+        "kubernetes.io/service-account.name" = "default"
+      }
+    }
+    type = "kubernetes.io/service-account-token"
+  }
+  computed_fields = ["metadata.annotations", "metadata.labels", "data"]
+}
+
+// the token is filled in by the token controller after the Secret is created
+data "kubernetes_resource" "token" {
+  api_version = "v1"
+  kind        = "Secret"
+
+  metadata {
+    name      = var.name
+    namespace = var.namespace
+  }
+
+  wait {
+    fields = {
+      "data.token" = "*"
+    }
+    timeout = "2m"
+  }
+
+  depends_on = [kubernetes_manifest.token]
+}
@@ -0,0 +1,18 @@
+# Copyright IBM Corp. 2017, 2026
+# SPDX-License-Identifier: MPL-2.0
+
+data "kubernetes_resources" "fields" {
+    kind = "ConfigMap"
+    api_version = "v1"
+    namespace = var.namespace
+    label_selector = var.label_selector
+    fields = ["metadata.name", "data"]
+}
+
+data "kubernetes_resources" "metadata" {
+    kind = "ConfigMap"
+    api_version = "v1"
+    namespace = var.namespace
+    label_selector = var.label_selector
+    metadata_only = true
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
@@ -151,6 +152,20 @@ func (k *Helper) DeleteResource(t *testing.T, name string, gvr schema.GroupVersi
 	}
 }
 
+// PatchNamespacedResource applies a JSON merge patch to a namespaced resource, as the given field manager
+func (k *Helper) PatchNamespacedResource(t *testing.T, name string, namespace string, gvr schema.GroupVersionResource, patch map[string]interface{}, fieldManager string) {
+	t.Helper()
+
+	js, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Failed to marshal patch: %v", err)
+	}
+	_, err = k.dynClient.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, types.MergePatchType, js, metav1.PatchOptions{FieldManager: fieldManager})
+	if err != nil {
+		t.Fatalf("Failed to patch resource \"%s/%s\": %v", namespace, name, err)
+	}
+}
+
 // DeleteResource deletes a namespaced resource referred to by the name and GVK
 func (k *Helper) DeleteNamespacedResource(t *testing.T, name string, namespace string, gvr schema.GroupVersionResource) {
 	t.Helper()
@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Labels and annotations defined by the ApplySet specification (KEP-3659),
+// which are also used by "kubectl apply --prune --applyset".
+const (
+	// ApplySetPartOfLabel is set on every member object of an ApplySet to the ID of the set.
+	ApplySetPartOfLabel = "applyset.kubernetes.io/part-of"
+	// ApplySetParentIDLabel is set on the parent object of an ApplySet to the ID of the set.
+	ApplySetParentIDLabel = "applyset.kubernetes.io/id"
+	// ApplySetToolingAnnotation records the tool that manages the ApplySet on its parent object.
+	ApplySetToolingAnnotation = "applyset.kubernetes.io/tooling"
+	// ApplySetGKsAnnotation lists the group-kinds of the ApplySet members on its parent object.
+	ApplySetGKsAnnotation = "applyset.kubernetes.io/contains-group-kinds"
+	// ApplySetAdditionalNamespacesAnnotation lists the namespaces of the ApplySet members
+	// other than the namespace of the parent object.
+	ApplySetAdditionalNamespacesAnnotation = "applyset.kubernetes.io/additional-namespaces"
+
+	// ApplySetTooling is the value of the tooling annotation for ApplySets managed by this provider.
+	ApplySetTooling = "terraform-provider-kubernetes/v1"
+)
+
+// ApplySetID returns the ApplySet ID for a set whose parent is the Secret 'name' in 'namespace'.
+//
+// The ID is derived from the identity of the parent object, as described in the ApplySet specification:
+// "applyset-" + base64url(sha256(<name>.<namespace>.<kind>.<group>)) + "-v1"
+func ApplySetID(name, namespace string) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{name, namespace, "Secret", ""}, ".")))
+	return fmt.Sprintf("applyset-%s-v1", base64.RawURLEncoding.EncodeToString(h[:]))
+}
+
+// ParseApplySetParent parses a reference to the parent Secret of an ApplySet.
+//
+// The expected format is "<namespace>/<name>".
+func ParseApplySetParent(ref string) (name string, namespace string, err error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("could not parse ApplySet parent: %q. Parent must be in <namespace>/<name> format", ref)
+	}
+	return parts[1], parts[0], nil
+}
@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"testing"
+)
+
+func TestApplySetID(t *testing.T) {
+	// base64url(sha256("test.default.Secret."))
+	id := ApplySetID("test", "default")
+	if id != "applyset-6o8HvWRelAdA5AaGpfBxt6jCWO3V6NmDG09EauFK3DQ-v1" {
+		t.Fatalf("unexpected ApplySet ID: %s", id)
+	}
+	if ApplySetID("test", "other") == id {
+		t.Fatal("expected ApplySet IDs to differ across namespaces")
+	}
+}
+
+func TestParseApplySetParent(t *testing.T) {
+	cases := []struct {
+		ref       string
+		name      string
+		namespace string
+		err       bool
+	}{
+		{ref: "default/test", name: "test", namespace: "default"},
+		{ref: "test", err: true},
+		{ref: "/test", err: true},
+		{ref: "default/", err: true},
+		{ref: "a/b/c", err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.ref, func(t *testing.T) {
+			name, namespace, err := ParseApplySetParent(c.ref)
+			if c.err {
+				if err == nil {
+					t.Fatalf("expected error for %q", c.ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != c.name || namespace != c.namespace {
+				t.Fatalf("expected %s/%s, got %s/%s", c.namespace, c.name, namespace, name)
+			}
+		})
+	}
+}
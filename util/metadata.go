@@ -0,0 +1,20 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+// MergeDefaults returns the annotations or labels of an object with the default values of the provider
+// added for the keys the object does not set itself
+func MergeDefaults(m, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return m
+	}
+	out := make(map[string]string, len(m)+len(defaults))
+	for k, v := range defaults {
+		out[k] = v
+	}
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,24 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	defaults := map[string]string{"team": "platform", "env": "production"}
+	out := MergeDefaults(map[string]string{"app": "web", "team": "data"}, defaults)
+	expected := map[string]string{"app": "web", "team": "data", "env": "production"}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+	if out := MergeDefaults(nil, defaults); !reflect.DeepEqual(out, defaults) {
+		t.Fatalf("expected %v, got %v", defaults, out)
+	}
+	if out := MergeDefaults(nil, nil); out != nil {
+		t.Fatalf("expected nil, got %v", out)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultMaxRetries is the number of times a request to the Kubernetes API is retried after a transient error,
+// unless set otherwise with the 'max_retries' provider attribute.
+const DefaultMaxRetries = 5
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// transientErrorMessages are parts of the messages of the errors returned by the API server
+// when a request failed for a transient reason, like an upgrade of the control plane.
+var transientErrorMessages = []string{
+	"etcdserver: leader changed",
+	"etcdserver: request timed out",
+	"etcdserver: too many requests",
+	"context deadline exceeded",
+	"the server is currently unable to handle the request",
+}
+
+// RetryTransport returns a transport wrapper that retries the requests to the Kubernetes API failing
+// for a transient reason, up to maxRetries times, with an exponential backoff.
+//
+// Responses carrying a "Retry-After" header are left to the client-go REST client, which already retries them.
+// Throttled requests, answered with "429 Too Many Requests", were not processed and are always retried.
+// Requests that could not be sent, because the connection to the API server could not be established,
+// are retried as well. Other transient errors, like server errors and reset connections, are only retried
+// for idempotent requests, as the request may have been processed: updates and deletions are not resent then,
+// as a second attempt would fail on the changed resource version or the deleted object.
+func RetryTransport(maxRetries int) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		if maxRetries <= 0 {
+			return rt
+		}
+		return &retryRoundTripper{
+			delegate:   rt,
+			maxRetries: maxRetries,
+			baseDelay:  retryBaseDelay,
+			maxDelay:   retryMaxDelay,
+		}
+	}
+}
+
+type retryRoundTripper struct {
+	delegate   http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func (r *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Query().Get("watch") == "true" || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		// watches are long running, and requests whose body cannot be sent again cannot be retried
+		return r.delegate.RoundTrip(req)
+	}
+	for attempt := 0; ; attempt++ {
+		rq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			rq = req.Clone(req.Context())
+			rq.Body = body
+		}
+		resp, err := r.delegate.RoundTrip(rq)
+		if attempt >= r.maxRetries {
+			return resp, err
+		}
+		delay, reason := r.retryDelay(rq, resp, err, attempt)
+		if reason == "" {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("[DEBUG] Retrying %s %s in %s (%d/%d): %s", req.Method, req.URL.Path, delay, attempt+1, r.maxRetries, reason)
+		t := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		case <-t.C:
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request, and the reason to retry it.
+// The reason is empty when the request is not to be retried.
+func (r *retryRoundTripper) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, string) {
+	delay := r.baseDelay << attempt
+	if delay <= 0 || delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	// add up to 20% of jitter so that the requests of concurrent operations are spread out
+	delay += time.Duration(rand.Int63n(int64(delay)/5 + 1))
+
+	if err != nil {
+		if req.Context().Err() != nil {
+			return 0, ""
+		}
+		if isDialError(err) {
+			// the request was not sent
+			return delay, err.Error()
+		}
+		if isIdempotent(req) && isTransientNetworkError(err) {
+			return delay, err.Error()
+		}
+		return 0, ""
+	}
+
+	if resp.Header.Get("Retry-After") != "" {
+		// the REST client of client-go retries the responses asking for a retry
+		return 0, ""
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return delay, resp.Status
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(req) {
+			return 0, ""
+		}
+		return delay, resp.Status
+	case http.StatusInternalServerError:
+		if !isIdempotent(req) {
+			return 0, ""
+		}
+		// the body is read to find out the cause of the error, and restored for the caller
+		body, rerr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if rerr != nil {
+			return 0, ""
+		}
+		for _, m := range transientErrorMessages {
+			if strings.Contains(string(body), m) {
+				return delay, m
+			}
+		}
+	}
+	return 0, ""
+}
+
+// isIdempotent reports whether sending a request more than once has the same effect as sending it once.
+// Patches are idempotent, as they set the fields of the object to the values they contain,
+// except for JSON patches which can add elements to lists. Updates and deletions are not: once processed,
+// sending them again fails as the resource version of the object changed, or the object is gone.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPatch:
+		return !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json-patch+json")
+	}
+	return false
+}
+
+// isDialError reports whether the connection to the API server could not be established,
+// in which case the request was not sent
+func isDialError(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var oe *net.OpError
+	return errors.As(err, &oe) && oe.Op == "dial"
+}
+
+// isTransientNetworkError reports whether the connection to the API server failed for a transient reason
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return strings.Contains(err.Error(), "connection reset by peer") || strings.Contains(err.Error(), "http2: server sent GOAWAY")
+}
@@ -0,0 +1,209 @@
+// Copyright IBM Corp. 2017, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		method      string
+		contentType string
+		responses   []int
+		body        string
+		retryAfter  string
+		requests    int
+		status      int
+	}{
+		"throttled": {
+			method:    http.MethodPost,
+			responses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusCreated},
+			requests:  3,
+			status:    http.StatusCreated,
+		},
+		"leader changed": {
+			method:    http.MethodGet,
+			responses: []int{http.StatusInternalServerError, http.StatusOK},
+			body:      `{"kind":"Status","message":"etcdserver: leader changed","code":500}`,
+			requests:  2,
+			status:    http.StatusOK,
+		},
+		"server error": {
+			method:    http.MethodGet,
+			responses: []int{http.StatusInternalServerError, http.StatusOK},
+			body:      `{"kind":"Status","message":"internal error","code":500}`,
+			requests:  1,
+			status:    http.StatusInternalServerError,
+		},
+		"unavailable create": {
+			method:    http.MethodPost,
+			responses: []int{http.StatusServiceUnavailable, http.StatusCreated},
+			requests:  1,
+			status:    http.StatusServiceUnavailable,
+		},
+		"unavailable apply": {
+			method:      http.MethodPatch,
+			contentType: "application/apply-patch+yaml",
+			responses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			requests:    2,
+			status:      http.StatusOK,
+		},
+		"unavailable json patch": {
+			method:      http.MethodPatch,
+			contentType: "application/json-patch+json",
+			responses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			requests:    1,
+			status:      http.StatusServiceUnavailable,
+		},
+		"throttled with retry-after": {
+			method:     http.MethodGet,
+			responses:  []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "1",
+			requests:   1,
+			status:     http.StatusTooManyRequests,
+		},
+		"unavailable update": {
+			method:    http.MethodPut,
+			responses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			requests:  1,
+			status:    http.StatusServiceUnavailable,
+		},
+		"unavailable delete": {
+			method:    http.MethodDelete,
+			responses: []int{http.StatusGatewayTimeout, http.StatusOK},
+			requests:  1,
+			status:    http.StatusGatewayTimeout,
+		},
+		"retries exhausted": {
+			method:    http.MethodGet,
+			responses: []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout},
+			requests:  3,
+			status:    http.StatusGatewayTimeout,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("unexpected request body: %q", body)
+				}
+				if c.retryAfter != "" {
+					w.Header().Set("Retry-After", c.retryAfter)
+				}
+				w.WriteHeader(c.responses[requests])
+				if c.responses[requests] != http.StatusOK && c.responses[requests] != http.StatusCreated {
+					w.Write([]byte(c.body))
+				}
+				requests++
+			}))
+			defer srv.Close()
+
+			rt := RetryTransport(2)(http.DefaultTransport).(*retryRoundTripper)
+			rt.baseDelay = time.Millisecond
+			req, err := http.NewRequest(c.method, srv.URL, bytes.NewReader([]byte("payload")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.contentType != "" {
+				req.Header.Set("Content-Type", c.contentType)
+			}
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != c.status {
+				t.Fatalf("expected status %d, got %d", c.status, resp.StatusCode)
+			}
+			if requests != c.requests {
+				t.Fatalf("expected %d requests, got %d", c.requests, requests)
+			}
+			if c.status == http.StatusInternalServerError {
+				// the body of the response is still readable when it is not retried
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != c.body {
+					t.Fatalf("unexpected response body: %q", body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportDisabled(t *testing.T) {
+	if _, ok := RetryTransport(0)(http.DefaultTransport).(*retryRoundTripper); ok {
+		t.Fatal("expected no retries when max_retries is 0")
+	}
+}
+
+// failingTransport fails the first requests with an error, and answers the next ones with "200 OK"
+type failingTransport struct {
+	errs     []error
+	requests int
+}
+
+func (f *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests++
+	if f.requests <= len(f.errs) {
+		return nil, f.errs[f.requests-1]
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRetryTransportConnectionErrors(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		err      error
+		requests int
+	}{
+		"update not sent": {
+			method:   http.MethodPut,
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")},
+			requests: 2,
+		},
+		"delete not sent": {
+			method:   http.MethodDelete,
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			requests: 2,
+		},
+		"update reset": {
+			method:   http.MethodPut,
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			requests: 1,
+		},
+		"read reset": {
+			method:   http.MethodGet,
+			err:      &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			requests: 2,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ft := &failingTransport{errs: []error{c.err}}
+			rt := RetryTransport(2)(ft).(*retryRoundTripper)
+			rt.baseDelay = time.Millisecond
+			req, err := http.NewRequest(c.method, "https://127.0.0.1:6443/api/v1/namespaces/default", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := rt.RoundTrip(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if ft.requests != c.requests {
+				t.Fatalf("expected %d requests, got %d", c.requests, ft.requests)
+			}
+		})
+	}
+}